@@ -0,0 +1,242 @@
+// Copyright © 2018 Camunda Services GmbH (info@camunda.com)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/camunda-community-hub/zeebe-client-go/v8/pkg/bulk"
+	"github.com/camunda-community-hub/zeebe-client-go/v8/pkg/commands"
+	"github.com/spf13/cobra"
+)
+
+var (
+	bulkKeysFlag         string
+	bulkConcurrencyFlag  int
+	bulkRateFlag         float64
+	bulkCheckpointFlag   string
+	bulkProgressFlag     bool
+	bulkRetriesFlag      int32
+	bulkVariablesFlag    string
+	bulkLocalFlag        bool
+	bulkReportOutputFlag string
+)
+
+type BulkReportWrapper struct {
+	report *bulk.Report
+}
+
+func (b BulkReportWrapper) human() (string, error) {
+	lines := []string{fmt.Sprintf("Processed %d key(s): %d succeeded, %d failed, %d skipped, %d canceled",
+		b.report.Total, b.report.Succeeded, b.report.Failed, b.report.Skipped, b.report.Canceled)}
+
+	for _, result := range b.report.Results {
+		if result.Status == bulk.StatusFailed {
+			lines = append(lines, fmt.Sprintf("  %d: %s", result.Key, result.Error))
+		}
+	}
+
+	return strings.Join(lines, "\n"), nil
+}
+
+func (b BulkReportWrapper) json() (string, error) {
+	output, err := json.MarshalIndent(b.report, "", "  ")
+	return string(output), err
+}
+
+// readBulkKeys reads the keys from the file given by the keys flag, or from stdin if it is '-'
+func readBulkKeys() ([]int64, error) {
+	var reader io.Reader = os.Stdin
+	if bulkKeysFlag != "-" {
+		file, err := os.Open(bulkKeysFlag)
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+		reader = file
+	}
+
+	return bulk.ReadKeys(reader)
+}
+
+// runBulk runs the operation over the keys and prints the report. Interrupting the command stops starting new
+// operations, the keys not processed yet are reported as canceled and are not recorded in the checkpoint.
+func runBulk(operation bulk.Operation) error {
+	keys, err := readBulkKeys()
+	if err != nil {
+		return err
+	}
+
+	options := bulk.Options{
+		Concurrency:    bulkConcurrencyFlag,
+		RateLimit:      bulkRateFlag,
+		RequestTimeout: timeoutFlag,
+	}
+
+	if bulkCheckpointFlag != "" {
+		checkpoint, err := bulk.OpenCheckpoint(bulkCheckpointFlag)
+		if err != nil {
+			return err
+		}
+		defer checkpoint.Close()
+		options.Checkpoint = checkpoint
+	}
+
+	if bulkProgressFlag {
+		options.OnProgress = func(progress bulk.Progress) {
+			fmt.Fprintf(os.Stderr, "\rProcessed %d/%d key(s), %d failed", progress.Done, progress.Total, progress.Failed)
+			if progress.Done == progress.Total {
+				fmt.Fprintln(os.Stderr)
+			}
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	report, err := bulk.Run(ctx, keys, operation, options)
+	if err != nil {
+		return err
+	}
+
+	if bulkReportOutputFlag != "" {
+		output, err := BulkReportWrapper{report}.json()
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(bulkReportOutputFlag, []byte(output+"\n"), 0600); err != nil {
+			return err
+		}
+	}
+
+	if err := printOutput(BulkReportWrapper{report}); err != nil {
+		return err
+	}
+
+	if report.Failed > 0 || report.Canceled > 0 {
+		return fmt.Errorf("%d key(s) failed and %d key(s) were canceled", report.Failed, report.Canceled)
+	}
+	return nil
+}
+
+var bulkCmd = &cobra.Command{
+	Use:   "bulk",
+	Short: "Run a command over many keys",
+	Long: "Run a command over many keys, read from a file or stdin separated by whitespace or commas. Keys" +
+		" processed successfully are recorded in the checkpoint file, if any, and are skipped when the command is run" +
+		" again with the same checkpoint.",
+}
+
+var bulkCancelCmd = &cobra.Command{
+	Use:   "cancel",
+	Short: "Cancel many resources",
+}
+
+var bulkCancelInstanceCmd = &cobra.Command{
+	Use:     "instance",
+	Short:   "Cancel the process instances with the given keys",
+	Args:    cobra.NoArgs,
+	PreRunE: initClient,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runBulk(bulk.CancelInstance(client))
+	},
+}
+
+var bulkResolveCmd = &cobra.Command{
+	Use:   "resolve",
+	Short: "Resolve many resources",
+}
+
+var bulkResolveIncidentCmd = &cobra.Command{
+	Use:     "incident",
+	Short:   "Resolve the incidents with the given keys",
+	Args:    cobra.NoArgs,
+	PreRunE: initClient,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runBulk(bulk.ResolveIncident(client))
+	},
+}
+
+var bulkUpdateCmd = &cobra.Command{
+	Use:   "update",
+	Short: "Update many resources",
+}
+
+var bulkUpdateRetriesCmd = &cobra.Command{
+	Use:     "retries",
+	Short:   "Update the retries of the jobs with the given keys",
+	Args:    cobra.NoArgs,
+	PreRunE: initClient,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runBulk(bulk.UpdateJobRetries(client, bulkRetriesFlag))
+	},
+}
+
+var bulkSetCmd = &cobra.Command{
+	Use:   "set",
+	Short: "Set many resources",
+}
+
+var bulkSetVariablesCmd = &cobra.Command{
+	Use:     "variables",
+	Short:   "Set the variables of the element instances with the given keys",
+	Args:    cobra.NoArgs,
+	PreRunE: initClient,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runBulk(bulk.SetVariables(client, bulkVariablesFlag, bulkLocalFlag))
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(bulkCmd)
+
+	bulkCmd.PersistentFlags().StringVar(&bulkKeysFlag, "keys", "", "Specify the file to read the keys from, '-' reads them from stdin")
+	if err := bulkCmd.MarkPersistentFlagRequired("keys"); err != nil {
+		panic(err)
+	}
+	bulkCmd.PersistentFlags().IntVar(&bulkConcurrencyFlag, "concurrency", bulk.DefaultConcurrency, "Specify the maximum number of requests sent at the same time")
+	bulkCmd.PersistentFlags().Float64Var(&bulkRateFlag, "rate", 0, "Specify the maximum number of requests sent per second, 0 means no limit")
+	bulkCmd.PersistentFlags().StringVar(&bulkCheckpointFlag, "checkpoint", "", "Specify a file recording the processed keys, to resume an interrupted run")
+	bulkCmd.PersistentFlags().BoolVar(&bulkProgressFlag, "progress", false, "Report the progress on stderr")
+	bulkCmd.PersistentFlags().StringVar(&bulkReportOutputFlag, "report", "", "Specify a file to write the per-key report to as JSON")
+
+	for parent, command := range map[*cobra.Command]*cobra.Command{
+		bulkCancelCmd:  bulkCancelInstanceCmd,
+		bulkResolveCmd: bulkResolveIncidentCmd,
+		bulkUpdateCmd:  bulkUpdateRetriesCmd,
+		bulkSetCmd:     bulkSetVariablesCmd,
+	} {
+		addOutputFlag(command)
+		parent.AddCommand(command)
+		bulkCmd.AddCommand(parent)
+	}
+
+	bulkUpdateRetriesCmd.Flags().Int32Var(&bulkRetriesFlag, "retries", commands.DefaultJobRetries, "Specify retries of the jobs")
+	if err := bulkUpdateRetriesCmd.MarkFlagRequired("retries"); err != nil {
+		panic(err)
+	}
+
+	bulkSetVariablesCmd.Flags().StringVar(&bulkVariablesFlag, "variables", "{}", "Specify the variables as JSON object string")
+	if err := bulkSetVariablesCmd.MarkFlagRequired("variables"); err != nil {
+		panic(err)
+	}
+	bulkSetVariablesCmd.Flags().BoolVar(&bulkLocalFlag, "local", false, "Specify local or propagating update semantics")
+}
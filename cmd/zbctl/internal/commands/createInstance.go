@@ -16,18 +16,43 @@ package commands
 
 import (
 	"context"
+	"fmt"
 	"github.com/camunda-community-hub/zeebe-client-go/v8/pkg/commands"
+	"github.com/camunda-community-hub/zeebe-client-go/v8/pkg/model"
 	"github.com/spf13/cobra"
+	"os"
 	"strconv"
 	"strings"
 )
 
 var (
-	createInstanceVersionFlag    int32
-	createInstanceVariablesFlag  string
-	createInstanceWithResultFlag []string
+	createInstanceVersionFlag      int32
+	createInstanceVariablesFlag    string
+	createInstanceWithResultFlag   []string
+	createInstanceStartBeforeFlag  []string
+	createInstanceValidateWithFlag string
 )
 
+// validateStartElements checks that the process can be started before the elements, against the BPMN file of the
+// validateWith flag, if any. The process id is empty if the process is given by its key.
+func validateStartElements(processID string, elementIDs []string) error {
+	if createInstanceValidateWithFlag == "" {
+		return nil
+	}
+
+	content, err := os.ReadFile(createInstanceValidateWithFlag)
+	if err != nil {
+		return err
+	}
+
+	definitions, err := model.ParseBPMN(content)
+	if err != nil {
+		return fmt.Errorf("failed to parse '%s': %w", createInstanceValidateWithFlag, err)
+	}
+
+	return definitions.ValidateStartElements(processID, elementIDs...)
+}
+
 var createInstanceCmd = &cobra.Command{
 	Use:     "instance <processId or processKey>",
 	Short:   "Creates new process instance defined by the process ID or process key",
@@ -35,10 +60,12 @@ var createInstanceCmd = &cobra.Command{
 	PreRunE: initClient,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		var zbCmd commands.CreateInstanceCommandStep3
+		var processID string
 
 		processKey, err := strconv.Atoi(args[0])
 		if err != nil {
 			// Process ID given
+			processID = args[0]
 			zbCmd = client.NewCreateInstanceCommand().
 				BPMNProcessId(args[0]).
 				Version(createInstanceVersionFlag)
@@ -53,6 +80,18 @@ var createInstanceCmd = &cobra.Command{
 			return err
 		}
 
+		var startElementIDs []string
+		for _, elementID := range createInstanceStartBeforeFlag {
+			if trimmedElementID := strings.TrimSpace(elementID); trimmedElementID != "" {
+				zbCmd = zbCmd.StartInstructions(commands.StartInstruction{ElementID: trimmedElementID})
+				startElementIDs = append(startElementIDs, trimmedElementID)
+			}
+		}
+
+		if err := validateStartElements(processID, startElementIDs); err != nil {
+			return err
+		}
+
 		ctx, cancel := context.WithTimeout(context.Background(), timeoutFlag)
 		defer cancel()
 
@@ -96,6 +135,14 @@ func init() {
 		Flags().
 		StringSliceVar(&createInstanceWithResultFlag, "withResult", nil, "Specify to await result of process, optional a list of variable names can be provided to limit the returned variables")
 
+	createInstanceCmd.
+		Flags().
+		StringSliceVar(&createInstanceStartBeforeFlag, "startBefore", nil, "Specify the ids of the elements to start the process instance before, instead of its start event")
+
+	createInstanceCmd.
+		Flags().
+		StringVar(&createInstanceValidateWithFlag, "validateWith", "", "Specify the BPMN file of the process to check the start instructions against before creating the instance")
+
 	// hack to use --withResult without values
 	createInstanceCmd.Flag("withResult").NoOptDefVal = " "
 }
@@ -28,8 +28,12 @@ var deployCmd = &cobra.Command{
 	Short:   "Deploys new resources for each file provided",
 	Long:    "Deploys new resources for each file provided. The `deploy <processPath>...` usage is deprecated, to be removed in 8.1. Please use the `deploy resource` subcommand.",
 	Args:    cobra.MinimumNArgs(1),
-	PreRunE: initClient,
+	PreRunE: initClientUnlessValidating,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if validateOnlyFlag {
+			return validateResources(args)
+		}
+
 		if len(resourceNamesFlag) > len(args) {
 			return fmt.Errorf("there are more resource names (%d) than process paths (%d)", len(resourceNamesFlag), len(args))
 		}
@@ -62,6 +66,7 @@ var deployCmd = &cobra.Command{
 
 func init() {
 	rootCmd.AddCommand(deployCmd)
+	addValidateOnlyFlag(deployCmd)
 
 	deployCmd.Flags().StringSliceVar(&resourceNamesFlag, "resourceNames", nil, "Resource names"+
 		" for the processes paths passed as arguments. The resource names are matched to processes by position. If a"+
@@ -17,11 +17,114 @@ package commands
 import (
 	"context"
 	"fmt"
+	"github.com/camunda-community-hub/zeebe-client-go/v8/pkg/commands"
+	"github.com/camunda-community-hub/zeebe-client-go/v8/pkg/entities"
 	"github.com/spf13/cobra"
 	"os"
+	"strings"
 )
 
-var resourceNamesFlag []string
+var (
+	resourceNamesFlag []string
+	validateOnlyFlag  bool
+)
+
+type DeploymentResultWrapper struct {
+	result *entities.DeploymentResult
+}
+
+func (d DeploymentResultWrapper) json() (string, error) {
+	return toJSON(d.result.DeployResourceResponse)
+}
+
+func (d DeploymentResultWrapper) human() (string, error) {
+	var lines []string
+
+	lines = append(lines, fmt.Sprintf("Deployment key: %d", d.result.Key))
+	if d.result.TenantId != "" {
+		lines = append(lines, fmt.Sprintf("Tenant: %s", d.result.TenantId))
+	}
+
+	if processes := d.result.Processes(); len(processes) > 0 {
+		lines = append(lines, "Processes:")
+		for _, process := range processes {
+			lines = append(lines, fmt.Sprintf("  %s - version %d, key %d, from %s",
+				process.BpmnProcessId, process.Version, process.ProcessDefinitionKey, process.ResourceName))
+		}
+	}
+
+	if drgs := d.result.DRGs(); len(drgs) > 0 {
+		lines = append(lines, "Decision requirements:")
+		for _, drg := range drgs {
+			lines = append(lines, fmt.Sprintf("  %s - version %d, key %d, from %s",
+				drg.DmnDecisionRequirementsId, drg.Version, drg.DecisionRequirementsKey, drg.ResourceName))
+		}
+	}
+
+	if decisions := d.result.Decisions(); len(decisions) > 0 {
+		lines = append(lines, "Decisions:")
+		for _, decision := range decisions {
+			lines = append(lines, fmt.Sprintf("  %s - version %d, key %d, in %s",
+				decision.DmnDecisionId, decision.Version, decision.DecisionKey, decision.DmnDecisionRequirementsId))
+		}
+	}
+
+	if forms := d.result.Forms(); len(forms) > 0 {
+		lines = append(lines, "Forms:")
+		for _, form := range forms {
+			lines = append(lines, fmt.Sprintf("  %s - version %d, key %d, from %s",
+				form.FormId, form.Version, form.FormKey, form.ResourceName))
+		}
+	}
+
+	return strings.Join(lines, "\n"), nil
+}
+
+// initClientUnlessValidating skips creating the client if the resources are only validated, as no gateway is needed
+func initClientUnlessValidating(cmd *cobra.Command, args []string) error {
+	if validateOnlyFlag {
+		return nil
+	}
+
+	return initClient(cmd, args)
+}
+
+// addResources adds the resources at the given paths, named after the resource names flag or their path
+func addResources(zbCmd *commands.DeployResourceCommand, paths []string) (*commands.DeployResourceCommand, error) {
+	if len(resourceNamesFlag) > len(paths) {
+		return nil, fmt.Errorf("there are more resource names (%d) than resource paths (%d)", len(resourceNamesFlag), len(paths))
+	}
+
+	for i := 0; i < len(resourceNamesFlag); i++ {
+		bytes, err := os.ReadFile(paths[i])
+		if err != nil {
+			return nil, err
+		}
+
+		zbCmd.AddResource(bytes, resourceNamesFlag[i])
+	}
+
+	for i := len(resourceNamesFlag); i < len(paths); i++ {
+		zbCmd = zbCmd.AddResourceFile(paths[i])
+	}
+
+	return zbCmd, nil
+}
+
+// validateResources checks the structure of the resources at the given paths locally, without deploying them
+func validateResources(paths []string) error {
+	zbCmd, err := addResources(commands.NewDeployResourceCommand(nil, nil), paths)
+	if err != nil {
+		return err
+	}
+
+	if err := zbCmd.Validate(); err != nil {
+		return err
+	}
+
+	fmt.Printf("No problems found in %d resource(s)\n", len(paths))
+	return nil
+}
 
 // Remove the nolint directive when this command is the only remaining one for deploy
 // nolint
@@ -29,42 +132,40 @@ var deployResourceCmd = &cobra.Command{
 	Use:     "resource <resourcePath>...",
 	Short:   "Deploys a new resource (e.g. process, decision) for each BPMN/DMN or Form resource provided",
 	Args:    cobra.MinimumNArgs(1),
-	PreRunE: initClient,
+	PreRunE: initClientUnlessValidating,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		if len(resourceNamesFlag) > len(args) {
-			return fmt.Errorf("there are more resource names (%d) than resource paths (%d)", len(resourceNamesFlag), len(args))
-		}
-
-		zbCmd := client.NewDeployResourceCommand()
-		for i := 0; i < len(resourceNamesFlag); i++ {
-			bytes, err := os.ReadFile(args[i])
-			if err != nil {
-				return err
-			}
-
-			zbCmd.AddResource(bytes, resourceNamesFlag[i])
+		if validateOnlyFlag {
+			return validateResources(args)
 		}
 
-		for i := len(resourceNamesFlag); i < len(args); i++ {
-			zbCmd = zbCmd.AddResourceFile(args[i])
+		zbCmd, err := addResources(client.NewDeployResourceCommand(), args)
+		if err != nil {
+			return err
 		}
 
 		ctx, cancel := context.WithTimeout(context.Background(), timeoutFlag)
 		defer cancel()
 
-		response, err := zbCmd.Send(ctx)
+		result, err := zbCmd.SendTyped(ctx)
 		if err != nil {
 			return err
 		}
 
-		return printJSON(response)
+		return printOutput(DeploymentResultWrapper{result})
 	},
 }
 
 func init() {
+	addOutputFlag(deployResourceCmd)
+	addValidateOnlyFlag(deployResourceCmd)
 	deployCmd.AddCommand(deployResourceCmd)
 
 	deployResourceCmd.Flags().StringSliceVar(&resourceNamesFlag, "resourceNames", nil, "Resource names"+
 		" for the resource paths passed as arguments. The resource names are matched to resources by position. If a"+
 		" resource does not have a matching resource name, the resource path is used instead")
 }
+
+func addValidateOnlyFlag(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&validateOnlyFlag, "validate-only", false, "Only check the structure of the BPMN,"+
+		" DMN and form resources locally, without deploying them")
+}
@@ -0,0 +1,231 @@
+// Copyright © 2018 Camunda Services GmbH (info@camunda.com)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/camunda-community-hub/zeebe-client-go/v8/pkg/model"
+	"github.com/spf13/cobra"
+	"os"
+	"sort"
+	"strings"
+)
+
+var handledJobTypesFlag []string
+
+type inspectedProcess struct {
+	ID         string `json:"id"`
+	Name       string `json:"name,omitempty"`
+	Executable bool   `json:"executable"`
+}
+
+type inspectedJobTask struct {
+	ProcessID string            `json:"processId"`
+	ElementID string            `json:"elementId"`
+	Name      string            `json:"name,omitempty"`
+	JobType   string            `json:"jobType"`
+	Retries   string            `json:"retries,omitempty"`
+	Headers   map[string]string `json:"headers,omitempty"`
+}
+
+type inspectedResource struct {
+	Resource        string             `json:"resource"`
+	Processes       []inspectedProcess `json:"processes"`
+	JobTasks        []inspectedJobTask `json:"jobTasks"`
+	Messages        []model.Message    `json:"messages"`
+	Signals         []model.Signal     `json:"signals"`
+	Errors          []model.BPMNError  `json:"errors"`
+	MissingJobTypes []string           `json:"missingJobTypes,omitempty"`
+}
+
+type InspectionWrapper struct {
+	resources []inspectedResource
+}
+
+func (i InspectionWrapper) json() (string, error) {
+	b, err := json.MarshalIndent(i.resources, "", "  ")
+	return string(b), err
+}
+
+func (i InspectionWrapper) human() (string, error) {
+	var lines []string
+
+	for _, resource := range i.resources {
+		lines = append(lines, resource.Resource)
+
+		lines = append(lines, "  Processes:")
+		for _, process := range resource.Processes {
+			line := "    " + process.ID
+			if process.Name != "" {
+				line += fmt.Sprintf(" (%s)", process.Name)
+			}
+			if !process.Executable {
+				line += " - not executable"
+			}
+			lines = append(lines, line)
+		}
+
+		if len(resource.JobTasks) > 0 {
+			lines = append(lines, "  Job types:")
+			for _, task := range resource.JobTasks {
+				retries := task.Retries
+				if retries == "" {
+					retries = "default"
+				}
+				line := fmt.Sprintf("    %s - element %s in process %s, retries %s", task.JobType, task.ElementID, task.ProcessID, retries)
+				if len(task.Headers) > 0 {
+					line += ", headers " + formatHeaders(task.Headers)
+				}
+				lines = append(lines, line)
+			}
+		}
+
+		if len(resource.Messages) > 0 {
+			lines = append(lines, "  Messages:")
+			for _, message := range resource.Messages {
+				line := "    " + message.Name
+				if message.CorrelationKey != "" {
+					line += fmt.Sprintf(" - correlation key %s", message.CorrelationKey)
+				}
+				lines = append(lines, line)
+			}
+		}
+
+		if len(resource.Signals) > 0 {
+			lines = append(lines, "  Signals:")
+			for _, signal := range resource.Signals {
+				lines = append(lines, "    "+signal.Name)
+			}
+		}
+
+		if len(resource.Errors) > 0 {
+			lines = append(lines, "  Errors:")
+			for _, bpmnError := range resource.Errors {
+				line := "    " + bpmnError.Code
+				if bpmnError.Name != "" {
+					line += fmt.Sprintf(" (%s)", bpmnError.Name)
+				}
+				lines = append(lines, line)
+			}
+		}
+
+		if len(resource.MissingJobTypes) > 0 {
+			lines = append(lines, "  Missing job handlers:")
+			for _, jobType := range resource.MissingJobTypes {
+				lines = append(lines, "    "+jobType)
+			}
+		}
+	}
+
+	return strings.Join(lines, "\n"), nil
+}
+
+func formatHeaders(headers map[string]string) string {
+	keys := make([]string, 0, len(headers))
+	for key := range headers {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		pairs = append(pairs, fmt.Sprintf("%s=%s", key, headers[key]))
+	}
+
+	return strings.Join(pairs, ", ")
+}
+
+func inspectResource(path string) (inspectedResource, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return inspectedResource{}, err
+	}
+
+	definitions, err := model.ParseBPMN(content)
+	if err != nil {
+		return inspectedResource{}, fmt.Errorf("failed to parse '%s': %w", path, err)
+	}
+
+	resource := inspectedResource{
+		Resource:  path,
+		Processes: []inspectedProcess{},
+		JobTasks:  []inspectedJobTask{},
+		Messages:  append([]model.Message{}, definitions.Messages()...),
+		Signals:   append([]model.Signal{}, definitions.Signals()...),
+		Errors:    append([]model.BPMNError{}, definitions.Errors()...),
+	}
+
+	for _, process := range definitions.Processes {
+		resource.Processes = append(resource.Processes, inspectedProcess{ID: process.ID, Name: process.Name, Executable: process.Executable})
+	}
+
+	for _, task := range definitions.JobTasks() {
+		resource.JobTasks = append(resource.JobTasks, inspectedJobTask{
+			ProcessID: task.ProcessID,
+			ElementID: task.ID,
+			Name:      task.Name,
+			JobType:   task.TaskType,
+			Retries:   task.TaskRetries,
+			Headers:   task.TaskHeaders,
+		})
+	}
+
+	if len(handledJobTypesFlag) > 0 {
+		resource.MissingJobTypes = definitions.MissingJobTypes(handledJobTypesFlag...)
+	}
+
+	return resource, nil
+}
+
+var inspectCmd = &cobra.Command{
+	Use:   "inspect <bpmnPath>...",
+	Short: "Lists the job types, messages, signals and errors of BPMN files",
+	Long: "Lists the processes, the job types with their retries and headers, and the messages, signals and errors of" +
+		" BPMN files, without contacting the gateway. If handled job types are given, job types without a handler are" +
+		" reported and the command fails.",
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		resources := make([]inspectedResource, 0, len(args))
+		missing := 0
+		for _, path := range args {
+			resource, err := inspectResource(path)
+			if err != nil {
+				return err
+			}
+
+			missing += len(resource.MissingJobTypes)
+			resources = append(resources, resource)
+		}
+
+		if err := printOutput(InspectionWrapper{resources}); err != nil {
+			return err
+		}
+
+		if missing > 0 {
+			return fmt.Errorf("found %d job type(s) without a handler", missing)
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	addOutputFlag(inspectCmd)
+	rootCmd.AddCommand(inspectCmd)
+
+	inspectCmd.Flags().StringSliceVar(&handledJobTypesFlag, "handlers", nil, "Job types handled by your job"+
+		" workers. Job types of the BPMN files without a handler are reported and make the command fail")
+}
@@ -44,6 +44,8 @@ var audienceFlag string
 var authzURLFlag string
 var insecureFlag bool
 var clientCacheFlag string
+var clientCacheTypeFlag string
+var clientCacheKeyPathFlag string
 var timeoutFlag time.Duration
 
 var rootCmd = &cobra.Command{
@@ -91,6 +93,8 @@ func init() {
 	rootCmd.PersistentFlags().StringVar(&authzURLFlag, "authzUrl", zbc.OAuthDefaultAuthzURL, "Specify an authorization server URL from which to request an access token. If omitted, will read from the environment variable '"+zbc.OAuthAuthorizationUrlEnvVar+"'")
 	rootCmd.PersistentFlags().BoolVar(&insecureFlag, "insecure", false, "Specify if zbctl should use an unsecured connection. If omitted, will read from the environment variable '"+zbc.InsecureEnvVar+"'")
 	rootCmd.PersistentFlags().StringVar(&clientCacheFlag, "clientCache", zbc.DefaultOauthYamlCachePath, "Specify the path to use for the OAuth credentials cache. If omitted, will read from the environment variable '"+zbc.OAuthCachePathEnvVar+"'")
+	rootCmd.PersistentFlags().StringVar(&clientCacheTypeFlag, "clientCacheType", "", "Specify the type of OAuth credentials cache: '"+zbc.OAuthYamlCacheType+"', '"+zbc.OAuthEncryptedCacheType+"' or '"+zbc.OAuthInMemoryCacheType+"'. If omitted, will read from the environment variable '"+zbc.OAuthCacheTypeEnvVar+"' (default '"+zbc.OAuthYamlCacheType+"')")
+	rootCmd.PersistentFlags().StringVar(&clientCacheKeyPathFlag, "clientCacheKeyPath", "", "Specify the path to a file containing the key for the encrypted OAuth credentials cache. If omitted, will read the key from the environment variable '"+zbc.OAuthCacheKeyEnvVar+"' or its path from '"+zbc.OAuthCacheKeyPathEnvVar+"'")
 	rootCmd.PersistentFlags().DurationVar(&timeoutFlag, "requestTimeout", defaultTimeout, "Specify the default timeout for all requests. Example values: 300ms, 50s or 1m")
 }
 
@@ -111,8 +115,9 @@ var initClient = func(cmd *cobra.Command, args []string) error {
 
 	_, idExists := os.LookupEnv(zbc.OAuthClientIdEnvVar)
 	_, secretExists := os.LookupEnv(zbc.OAuthClientSecretEnvVar)
+	_, subjectTokenExists := os.LookupEnv(zbc.OAuthSubjectTokenPathEnvVar)
 
-	if idExists || secretExists {
+	if idExists || secretExists || subjectTokenExists {
 		_, audienceExists := os.LookupEnv(zbc.OAuthTokenAudienceEnvVar)
 		if !audienceExists {
 			if err := os.Setenv(zbc.OAuthTokenAudienceEnvVar, host); err != nil {
@@ -170,9 +175,17 @@ func setSecurityParamsAsEnv() (err error) {
 	if shouldOverwriteEnvVar("authzUrl", zbc.OAuthAuthorizationUrlEnvVar) {
 		setEnv(zbc.OAuthAuthorizationUrlEnvVar, authzURLFlag)
 	}
-	if shouldOverwriteEnvVar("clientCache", zbc.DefaultOauthYamlCachePath) {
+	if clientCacheTypeFlag != "" {
+		setEnv(zbc.OAuthCacheTypeEnvVar, clientCacheTypeFlag)
+	}
+	// the encrypted cache uses its own default path, so as not to overwrite the plaintext cache
+	if shouldOverwriteEnvVar("clientCache", zbc.DefaultOauthYamlCachePath) &&
+		(rootCmd.Flags().Changed("clientCache") || os.Getenv(zbc.OAuthCacheTypeEnvVar) != zbc.OAuthEncryptedCacheType) {
 		setEnv(zbc.OAuthCachePathEnvVar, clientCacheFlag)
 	}
+	if clientCacheKeyPathFlag != "" {
+		setEnv(zbc.OAuthCacheKeyPathEnvVar, clientCacheKeyPathFlag)
+	}
 
 	return
 }
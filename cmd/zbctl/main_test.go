@@ -109,7 +109,7 @@ var tests = []testCase{
 	},
 	{
 		name:       "deploy resources",
-		cmd:        strings.Fields("--insecure deploy resource testdata/model.bpmn testdata/drg-force-user.dmn testdata/deploy_form.form --resourceNames=model.bpmn,drg-force-user.dmn,deploy_form.form"),
+		cmd:        strings.Fields("--insecure deploy resource testdata/model.bpmn testdata/drg-force-user.dmn testdata/deploy_form.form --resourceNames=model.bpmn,drg-force-user.dmn,deploy_form.form --output=json"),
 		goldenFile: "testdata/deploy_resources.golden",
 		jsonOutput: true,
 	},
@@ -15,8 +15,9 @@
 package utils
 
 import (
-	"encoding/json"
 	"fmt"
+
+	"github.com/camunda-community-hub/zeebe-client-go/v8/pkg/entities"
 )
 
 type SerializerMixin interface {
@@ -25,11 +26,12 @@ type SerializerMixin interface {
 }
 
 type JSONStringSerializer struct {
-	valueMap map[string]interface{}
+	valueMap   map[string]interface{}
+	serializer entities.Serializer
 }
 
 func (validator *JSONStringSerializer) Validate(name string, value string) error {
-	err := json.Unmarshal([]byte(value), &validator.valueMap)
+	err := validator.serializer.Unmarshal([]byte(value), &validator.valueMap)
 	if err != nil {
 		return fmt.Errorf("parameter %q requires a JSON object, got %q: %s", name, value, err)
 	}
@@ -40,7 +42,7 @@ func (validator *JSONStringSerializer) AsJSON(name string, value interface{}, ig
 	if ignoreOmitempty {
 		value = MapMarshal(value, "json", false, true)
 	}
-	b, err := json.Marshal(value)
+	b, err := validator.serializer.Marshal(value)
 	if err != nil {
 		return "", fmt.Errorf("parameter %q requires a JSON object, got %q: %s", name, value, err)
 	}
@@ -48,7 +50,13 @@ func (validator *JSONStringSerializer) AsJSON(name string, value interface{}, ig
 }
 
 func NewJSONStringSerializer() SerializerMixin {
+	return NewSerializerMixin(entities.JSONSerializer)
+}
+
+// NewSerializerMixin returns a SerializerMixin validating and encoding variables with the given serializer
+func NewSerializerMixin(serializer entities.Serializer) SerializerMixin {
 	return &JSONStringSerializer{
-		valueMap: make(map[string]interface{}),
+		valueMap:   make(map[string]interface{}),
+		serializer: serializer,
 	}
 }
@@ -0,0 +1,172 @@
+// Copyright © 2018 Camunda Services GmbH (info@camunda.com)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package bridge forwards records of a message source, such as a Kafka topic or a NATS subject, to Zeebe as published
+// messages. A record is acked once its message was published, or was already published before, and is nacked if it
+// could not be published. Adapting a broker only requires implementing Source.
+package bridge
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/camunda-community-hub/zeebe-client-go/v8/pkg/bulk"
+)
+
+// Record is a message to publish, received from a Source
+type Record struct {
+	Name           string
+	CorrelationKey string
+	// MessageID is optional, records without one get the ID derived by the MessageID function of the publisher options
+	MessageID  string
+	TenantID   string
+	TimeToLive time.Duration
+	// Payload is the JSON document of the message variables, empty for no variables
+	Payload json.RawMessage
+	// Position is set by sources to identify the record when it is acked or nacked, e.g. a partition offset
+	Position interface{}
+}
+
+// Source yields the records to publish. Ack and Nack may be called concurrently, and from other goroutines than Next.
+type Source interface {
+	// Next blocks until the next record is available, and returns io.EOF once the source is exhausted
+	Next(ctx context.Context) (Record, error)
+	// Ack is called once the message of the record was published
+	Ack(ctx context.Context, record Record) error
+	// Nack is called with the cause if the message of the record could not be published
+	Nack(ctx context.Context, record Record, cause error) error
+}
+
+// Options configure a Bridge
+type Options struct {
+	// Concurrency is the number of records published at the same time, defaults to 1 to ack records in order
+	Concurrency int
+	// Publisher configures how messages are published, e.g. how message IDs are derived
+	Publisher bulk.PublisherOptions
+	// OnResult is called with the outcome of each record, before it is acked or nacked
+	OnResult func(Record, bulk.PublishResult)
+}
+
+// Bridge publishes the records of a source as messages
+type Bridge struct {
+	source    Source
+	publisher *bulk.MessagePublisher
+	options   Options
+}
+
+func New(source Source, client bulk.MessageClient, options Options) *Bridge {
+	if options.Concurrency <= 0 {
+		options.Concurrency = 1
+	}
+
+	return &Bridge{
+		source:    source,
+		publisher: bulk.NewMessagePublisher(client, options.Publisher),
+		options:   options,
+	}
+}
+
+// Run publishes records until the source is exhausted, in which case it returns nil, or until ctx is done. It stops
+// with an error if the source fails to yield, ack or nack a record.
+func (bridge *Bridge) Run(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		publishing sync.WaitGroup
+		errOnce    sync.Once
+		runErr     error
+	)
+	fail := func(err error) {
+		errOnce.Do(func() {
+			runErr = err
+			cancel()
+		})
+	}
+
+	slots := make(chan struct{}, bridge.options.Concurrency)
+	for {
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		record, err := bridge.source.Next(ctx)
+		if err != nil {
+			<-slots
+			if !errors.Is(err, io.EOF) && ctx.Err() == nil {
+				fail(fmt.Errorf("failed to receive record: %w", err))
+			}
+			break
+		}
+
+		publishing.Add(1)
+		go func(record Record) {
+			defer publishing.Done()
+			defer func() { <-slots }()
+
+			if err := bridge.forward(ctx, record); err != nil {
+				fail(err)
+			}
+		}(record)
+	}
+	publishing.Wait()
+
+	if runErr != nil {
+		return runErr
+	}
+	return ctx.Err()
+}
+
+func (bridge *Bridge) forward(ctx context.Context, record Record) error {
+	message := bulk.Message{
+		Name:           record.Name,
+		CorrelationKey: record.CorrelationKey,
+		ID:             record.MessageID,
+		TimeToLive:     record.TimeToLive,
+		TenantID:       record.TenantID,
+	}
+	if len(record.Payload) > 0 {
+		message.Variables = record.Payload
+	}
+
+	result := bridge.publisher.Publish(ctx, []bulk.Message{message})[0]
+	if bridge.options.OnResult != nil {
+		bridge.options.OnResult(record, result)
+	}
+
+	if result.Succeeded() {
+		if err := bridge.source.Ack(ctx, record); err != nil {
+			return fmt.Errorf("failed to ack record: %w", err)
+		}
+		return nil
+	}
+
+	// records not published because the bridge is stopping are left to be received again
+	if ctx.Err() != nil {
+		return nil
+	}
+	if err := bridge.source.Nack(ctx, record, result.Err); err != nil {
+		return fmt.Errorf("failed to nack record: %w", err)
+	}
+	return nil
+}
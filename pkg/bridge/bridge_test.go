@@ -0,0 +1,174 @@
+// Copyright © 2018 Camunda Services GmbH (info@camunda.com)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bridge
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/camunda-community-hub/zeebe-client-go/v8/internal/mock_pb"
+	"github.com/camunda-community-hub/zeebe-client-go/v8/internal/utils"
+	"github.com/camunda-community-hub/zeebe-client-go/v8/pkg/bulk"
+	"github.com/camunda-community-hub/zeebe-client-go/v8/pkg/commands"
+	"github.com/camunda-community-hub/zeebe-client-go/v8/pkg/pb"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type testClient struct {
+	gateway pb.GatewayClient
+}
+
+func (c testClient) NewPublishMessageCommand() commands.PublishMessageCommandStep1 {
+	return commands.NewPublishMessageCommand(c.gateway, func(context.Context, error) bool { return false })
+}
+
+func TestBridgeAcksPublishedRecords(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gateway := mock_pb.NewMockGatewayClient(ctrl)
+	gateway.EXPECT().PublishMessage(gomock.Any(), &utils.RPCTestMsg{Msg: &pb.PublishMessageRequest{
+		Name:           "order-paid",
+		CorrelationKey: "order-1",
+		MessageId:      "order-paid/order-1",
+		TimeToLive:     60000,
+		Variables:      `{"amount":12}`,
+	}}).Return(&pb.PublishMessageResponse{Key: 1}, nil)
+	gateway.EXPECT().PublishMessage(gomock.Any(), &utils.RPCTestMsg{Msg: &pb.PublishMessageRequest{
+		Name:           "order-paid",
+		CorrelationKey: "order-2",
+		MessageId:      "event-2",
+	}}).Return(nil, status.Error(codes.AlreadyExists, "message already published"))
+	gateway.EXPECT().PublishMessage(gomock.Any(), &utils.RPCTestMsg{Msg: &pb.PublishMessageRequest{
+		Name:           "order-paid",
+		CorrelationKey: "order-3",
+		MessageId:      "order-paid/order-3",
+	}}).Return(nil, status.Error(codes.InvalidArgument, "invalid"))
+
+	source := NewMemorySource(3)
+	ctx := context.Background()
+	require.NoError(t, source.Add(ctx, Record{Name: "order-paid", CorrelationKey: "order-1", TimeToLive: time.Minute, Payload: json.RawMessage(`{"amount":12}`)}))
+	require.NoError(t, source.Add(ctx, Record{Name: "order-paid", CorrelationKey: "order-2", MessageID: "event-2"}))
+	require.NoError(t, source.Add(ctx, Record{Name: "order-paid", CorrelationKey: "order-3"}))
+	source.Close()
+
+	var results []bulk.PublishResult
+	bridge := New(source, testClient{gateway: gateway}, Options{
+		Publisher: bulk.PublisherOptions{
+			MessageID: func(message bulk.Message) string { return message.Name + "/" + message.CorrelationKey },
+		},
+		OnResult: func(_ Record, result bulk.PublishResult) { results = append(results, result) },
+	})
+
+	require.NoError(t, bridge.Run(ctx))
+
+	require.Len(t, source.Acked(), 2)
+	assert.Equal(t, "order-1", source.Acked()[0].CorrelationKey)
+	assert.Equal(t, "order-2", source.Acked()[1].CorrelationKey)
+	require.Len(t, source.Nacked(), 1)
+	assert.Equal(t, "order-3", source.Nacked()[0].CorrelationKey)
+	require.Len(t, results, 3)
+	assert.Equal(t, bulk.PublishStatusDuplicate, results[1].Status)
+}
+
+func TestBridgeStopsWhenContextIsDone(t *testing.T) {
+	source := NewMemorySource(1)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := New(source, testClient{}, Options{}).Run(ctx)
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.NoError(t, source.Add(context.Background(), Record{}))
+	source.Close()
+	assert.ErrorIs(t, source.Add(context.Background(), Record{}), ErrSourceClosed)
+}
+
+func TestBridgeWritesNackedNDJSONRecordsToDeadLetters(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gateway := mock_pb.NewMockGatewayClient(ctrl)
+	gateway.EXPECT().PublishMessage(gomock.Any(), gomock.Any()).Return(&pb.PublishMessageResponse{Key: 1}, nil)
+	gateway.EXPECT().PublishMessage(gomock.Any(), gomock.Any()).Return(nil, status.Error(codes.InvalidArgument, "invalid"))
+
+	input := strings.NewReader(`{"name": "order-paid", "correlationKey": "order-1"}
+
+{"name": "order-paid", "correlationKey": "order-2", "timeToLive": "1m", "variables": {"amount": 12}}
+`)
+	var deadLetters bytes.Buffer
+
+	err := New(NewNDJSONSource(input, &deadLetters), testClient{gateway: gateway}, Options{}).Run(context.Background())
+
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"name": "order-paid",
+		"correlationKey": "order-2",
+		"timeToLive": "1m0s",
+		"variables": {"amount": 12},
+		"error": "rpc error: code = InvalidArgument desc = invalid"
+	}`, deadLetters.String())
+}
+
+func TestNDJSONSource(t *testing.T) {
+	source := NewNDJSONSource(strings.NewReader(`{"name": "foo", "correlationKey": "bar", "messageId": "1", "tenantId": "tenant", "timeToLive": "10s", "variables": {"a": 1}}
+not json
+`), nil)
+	ctx := context.Background()
+
+	record, err := source.Next(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, Record{
+		Name:           "foo",
+		CorrelationKey: "bar",
+		MessageID:      "1",
+		TenantID:       "tenant",
+		TimeToLive:     10 * time.Second,
+		Payload:        json.RawMessage(`{"a": 1}`),
+		Position:       1,
+	}, record)
+
+	_, err = source.Next(ctx)
+	assert.ErrorContains(t, err, "invalid record on line 2")
+}
+
+func TestNDJSONSourceReadsLargeRecords(t *testing.T) {
+	large := fmt.Sprintf(`{"name": "foo", "correlationKey": "bar", "variables": {"data": "%s"}}`, strings.Repeat("x", 100*1024))
+	source := NewNDJSONSource(strings.NewReader(large+"\n"+large+"\n"), nil)
+	ctx := context.Background()
+
+	record, err := source.Next(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "foo", record.Name)
+	assert.Greater(t, len(record.Payload), 100*1024)
+
+	_, err = source.Next(ctx)
+	assert.NoError(t, err)
+
+	source = NewNDJSONSource(strings.NewReader(large+"\n"), nil).MaxRecordSize(1024)
+	_, err = source.Next(ctx)
+	assert.ErrorIs(t, err, bufio.ErrTooLong)
+	assert.ErrorContains(t, err, "record on line 1 exceeds the maximum size of 1024 bytes")
+}
@@ -0,0 +1,123 @@
+// Copyright © 2018 Camunda Services GmbH (info@camunda.com)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bridge
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+)
+
+// ErrSourceClosed is returned when adding records to a closed MemorySource
+var ErrSourceClosed = errors.New("source is closed")
+
+// MemorySource is a Source fed in process, e.g. by a consumer callback of a broker client or by tests
+type MemorySource struct {
+	records   chan Record
+	closeOnce sync.Once
+	closed    chan struct{}
+
+	mutex  sync.Mutex
+	acked  []Record
+	nacked []Record
+}
+
+// NewMemorySource returns a source buffering up to capacity records
+func NewMemorySource(capacity int) *MemorySource {
+	return &MemorySource{
+		records: make(chan Record, capacity),
+		closed:  make(chan struct{}),
+	}
+}
+
+// Add blocks until the record is buffered, or ctx is done
+func (source *MemorySource) Add(ctx context.Context, record Record) error {
+	select {
+	case <-source.closed:
+		return ErrSourceClosed
+	default:
+	}
+
+	select {
+	case source.records <- record:
+		return nil
+	case <-source.closed:
+		return ErrSourceClosed
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close makes Next return io.EOF once the buffered records were received
+func (source *MemorySource) Close() {
+	source.closeOnce.Do(func() {
+		close(source.closed)
+	})
+}
+
+func (source *MemorySource) Next(ctx context.Context) (Record, error) {
+	select {
+	case record := <-source.records:
+		return record, nil
+	default:
+	}
+
+	select {
+	case record := <-source.records:
+		return record, nil
+	case <-source.closed:
+		select {
+		case record := <-source.records:
+			return record, nil
+		default:
+			return Record{}, io.EOF
+		}
+	case <-ctx.Done():
+		return Record{}, ctx.Err()
+	}
+}
+
+func (source *MemorySource) Ack(_ context.Context, record Record) error {
+	source.mutex.Lock()
+	defer source.mutex.Unlock()
+
+	source.acked = append(source.acked, record)
+	return nil
+}
+
+func (source *MemorySource) Nack(_ context.Context, record Record, _ error) error {
+	source.mutex.Lock()
+	defer source.mutex.Unlock()
+
+	source.nacked = append(source.nacked, record)
+	return nil
+}
+
+// Acked returns the acked records, in the order they were acked
+func (source *MemorySource) Acked() []Record {
+	source.mutex.Lock()
+	defer source.mutex.Unlock()
+
+	return append([]Record{}, source.acked...)
+}
+
+// Nacked returns the nacked records, in the order they were nacked
+func (source *MemorySource) Nacked() []Record {
+	source.mutex.Lock()
+	defer source.mutex.Unlock()
+
+	return append([]Record{}, source.nacked...)
+}
@@ -0,0 +1,154 @@
+// Copyright © 2018 Camunda Services GmbH (info@camunda.com)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bridge
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultNDJSONMaxRecordSize is the default maximum size in bytes of a line read by NDJSONSource, matching the default
+// maximum message size of Zeebe
+const DefaultNDJSONMaxRecordSize = 4 * 1024 * 1024
+
+// ndjsonRecord is the JSON representation of a record, one per line:
+//
+//	{"name": "order-paid", "correlationKey": "order-1", "messageId": "event-1", "timeToLive": "1m", "variables": {"amount": 12}}
+type ndjsonRecord struct {
+	Name           string          `json:"name"`
+	CorrelationKey string          `json:"correlationKey"`
+	MessageID      string          `json:"messageId,omitempty"`
+	TenantID       string          `json:"tenantId,omitempty"`
+	TimeToLive     string          `json:"timeToLive,omitempty"`
+	Variables      json.RawMessage `json:"variables,omitempty"`
+	// Error is the cause of nacked records, written to the dead letters
+	Error string `json:"error,omitempty"`
+}
+
+// NDJSONSource reads records from newline delimited JSON, e.g. a file or stdin. The position of its records is their
+// line number. Nacked records are written to the dead letters writer, if any, in the same format with the cause of the
+// failure, so that they can be replayed later.
+type NDJSONSource struct {
+	scanner       *bufio.Scanner
+	maxRecordSize int
+	line          int
+
+	mutex       sync.Mutex
+	deadLetters io.Writer
+}
+
+// NewNDJSONSource returns a source reading records from reader, and writing nacked records to deadLetters if not nil
+func NewNDJSONSource(reader io.Reader, deadLetters io.Writer) *NDJSONSource {
+	source := &NDJSONSource{scanner: bufio.NewScanner(reader), deadLetters: deadLetters}
+	return source.MaxRecordSize(DefaultNDJSONMaxRecordSize)
+}
+
+// MaxRecordSize sets the maximum size in bytes of a line, DefaultNDJSONMaxRecordSize by default. Reading a longer line
+// fails. Like bufio.Scanner.Buffer, it panics if called after the first record was read.
+func (source *NDJSONSource) MaxRecordSize(size int) *NDJSONSource {
+	if size > 0 {
+		source.maxRecordSize = size
+		source.scanner.Buffer(make([]byte, 0, min(size, bufio.MaxScanTokenSize)), size)
+	}
+	return source
+}
+
+// Next returns the record of the next non-blank line. Reading is not interrupted when ctx is done, which only matters
+// for readers blocking for a long time such as stdin.
+func (source *NDJSONSource) Next(ctx context.Context) (Record, error) {
+	for source.scanner.Scan() {
+		source.line++
+		if err := ctx.Err(); err != nil {
+			return Record{}, err
+		}
+
+		line := strings.TrimSpace(source.scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		var value ndjsonRecord
+		if err := json.Unmarshal([]byte(line), &value); err != nil {
+			return Record{}, fmt.Errorf("invalid record on line %d: %w", source.line, err)
+		}
+
+		record := Record{
+			Name:           value.Name,
+			CorrelationKey: value.CorrelationKey,
+			MessageID:      value.MessageID,
+			TenantID:       value.TenantID,
+			Payload:        value.Variables,
+			Position:       source.line,
+		}
+		if value.TimeToLive != "" {
+			ttl, err := time.ParseDuration(value.TimeToLive)
+			if err != nil {
+				return Record{}, fmt.Errorf("invalid time to live on line %d: %w", source.line, err)
+			}
+			record.TimeToLive = ttl
+		}
+
+		return record, nil
+	}
+
+	if err := source.scanner.Err(); errors.Is(err, bufio.ErrTooLong) {
+		return Record{}, fmt.Errorf("record on line %d exceeds the maximum size of %d bytes: %w", source.line+1, source.maxRecordSize, err)
+	} else if err != nil {
+		return Record{}, err
+	}
+	return Record{}, io.EOF
+}
+
+func (source *NDJSONSource) Ack(context.Context, Record) error {
+	return nil
+}
+
+func (source *NDJSONSource) Nack(_ context.Context, record Record, cause error) error {
+	if source.deadLetters == nil {
+		return nil
+	}
+
+	value := ndjsonRecord{
+		Name:           record.Name,
+		CorrelationKey: record.CorrelationKey,
+		MessageID:      record.MessageID,
+		TenantID:       record.TenantID,
+		Variables:      record.Payload,
+	}
+	if record.TimeToLive > 0 {
+		value.TimeToLive = record.TimeToLive.String()
+	}
+	if cause != nil {
+		value.Error = cause.Error()
+	}
+
+	line, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	source.mutex.Lock()
+	defer source.mutex.Unlock()
+
+	_, err = fmt.Fprintf(source.deadLetters, "%s\n", line)
+	return err
+}
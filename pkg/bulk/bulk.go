@@ -0,0 +1,219 @@
+// Copyright © 2018 Camunda Services GmbH (info@camunda.com)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package bulk runs an operation, such as cancelling process instances or resolving incidents, over many keys with
+// bounded concurrency and an optional rate limit. Runs report their progress, can be resumed from a checkpoint file
+// and produce a per-key report. The MessagePublisher publishes batches of messages idempotently.
+package bulk
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultConcurrency    = 8
+	DefaultRequestTimeout = 10 * time.Second
+)
+
+// Operation is run for each key of a bulk run
+type Operation func(ctx context.Context, key int64) error
+
+// Status is the outcome of an operation for a key
+type Status string
+
+const (
+	StatusSucceeded Status = "succeeded"
+	StatusFailed    Status = "failed"
+	// StatusSkipped is the status of the keys already recorded in the checkpoint of the run
+	StatusSkipped Status = "skipped"
+	// StatusCanceled is the status of the keys not processed because the context of the run was done
+	StatusCanceled Status = "canceled"
+)
+
+// Result is the outcome of the operation for one key
+type Result struct {
+	Key    int64  `json:"key"`
+	Status Status `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// Report is the outcome of a bulk run, with the results in the order of the keys
+type Report struct {
+	Total     int      `json:"total"`
+	Succeeded int      `json:"succeeded"`
+	Failed    int      `json:"failed"`
+	Skipped   int      `json:"skipped"`
+	Canceled  int      `json:"canceled"`
+	Results   []Result `json:"results"`
+}
+
+// Progress is reported after each processed key
+type Progress struct {
+	Total     int
+	Done      int
+	Succeeded int
+	Failed    int
+	Skipped   int
+}
+
+// Options configure a bulk run
+type Options struct {
+	// Concurrency is the maximum number of operations running at the same time, defaults to DefaultConcurrency
+	Concurrency int
+	// RateLimit is the maximum number of operations started per second, zero or less means no limit
+	RateLimit float64
+	// RequestTimeout is the timeout of each operation, defaults to DefaultRequestTimeout
+	RequestTimeout time.Duration
+	// Checkpoint records the keys processed successfully, which are skipped by later runs using the same checkpoint
+	Checkpoint *Checkpoint
+	// OnProgress is called after each processed key, never concurrently
+	OnProgress func(Progress)
+}
+
+// Run runs the operation for each key. It returns an error only if the checkpoint cannot be written; the failures of
+// the operation are part of the report. If ctx is done, the keys not yet processed are reported as canceled.
+func Run(ctx context.Context, keys []int64, operation Operation, options Options) (*Report, error) {
+	concurrency := options.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultConcurrency
+	}
+	requestTimeout := options.RequestTimeout
+	if requestTimeout <= 0 {
+		requestTimeout = DefaultRequestTimeout
+	}
+
+	limiter := newRateLimiter(options.RateLimit)
+	defer limiter.stop()
+
+	report := &Report{Total: len(keys), Results: make([]Result, len(keys))}
+
+	var (
+		mutex         sync.Mutex
+		running       sync.WaitGroup
+		progress      = Progress{Total: len(keys)}
+		checkpointErr error
+	)
+	record := func(i int, result Result) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		report.Results[i] = result
+		progress.Done++
+		switch result.Status {
+		case StatusSucceeded:
+			progress.Succeeded++
+			if options.Checkpoint != nil && checkpointErr == nil {
+				checkpointErr = options.Checkpoint.Record(result.Key)
+			}
+		case StatusFailed:
+			progress.Failed++
+		case StatusSkipped:
+			progress.Skipped++
+		}
+		if options.OnProgress != nil {
+			options.OnProgress(progress)
+		}
+	}
+
+	slots := make(chan struct{}, concurrency)
+	for i, key := range keys {
+		if options.Checkpoint != nil && options.Checkpoint.Contains(key) {
+			record(i, Result{Key: key, Status: StatusSkipped})
+			continue
+		}
+
+		if err := limiter.wait(ctx); err != nil {
+			break
+		}
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		running.Add(1)
+		go func(i int, key int64) {
+			defer running.Done()
+			defer func() { <-slots }()
+
+			operationCtx, cancel := context.WithTimeout(ctx, requestTimeout)
+			defer cancel()
+
+			if err := operation(operationCtx, key); err != nil {
+				record(i, Result{Key: key, Status: StatusFailed, Error: err.Error()})
+			} else {
+				record(i, Result{Key: key, Status: StatusSucceeded})
+			}
+		}(i, key)
+	}
+	running.Wait()
+
+	for i, key := range keys {
+		if report.Results[i].Status == "" {
+			report.Results[i] = Result{Key: key, Status: StatusCanceled}
+		}
+
+		switch report.Results[i].Status {
+		case StatusSucceeded:
+			report.Succeeded++
+		case StatusFailed:
+			report.Failed++
+		case StatusSkipped:
+			report.Skipped++
+		case StatusCanceled:
+			report.Canceled++
+		}
+	}
+
+	if checkpointErr != nil {
+		return report, fmt.Errorf("failed to write checkpoint: %w", checkpointErr)
+	}
+	return report, nil
+}
+
+// rateLimiter spaces out the start of operations evenly
+type rateLimiter struct {
+	ticker *time.Ticker
+}
+
+func newRateLimiter(rate float64) *rateLimiter {
+	if rate <= 0 {
+		return &rateLimiter{}
+	}
+	return &rateLimiter{ticker: time.NewTicker(time.Duration(float64(time.Second) / rate))}
+}
+
+func (limiter *rateLimiter) wait(ctx context.Context) error {
+	if limiter.ticker == nil {
+		return ctx.Err()
+	}
+
+	select {
+	case <-limiter.ticker.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (limiter *rateLimiter) stop() {
+	if limiter.ticker != nil {
+		limiter.ticker.Stop()
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright © 2018 Camunda Services GmbH (info@camunda.com)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulk
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunReportsResultsInKeyOrder(t *testing.T) {
+	operation := func(_ context.Context, key int64) error {
+		if key == 2 {
+			return errors.New("not found")
+		}
+		return nil
+	}
+
+	var progress []Progress
+	report, err := Run(context.Background(), []int64{1, 2, 3}, operation, Options{
+		OnProgress: func(p Progress) { progress = append(progress, p) },
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, report.Total)
+	assert.Equal(t, 2, report.Succeeded)
+	assert.Equal(t, 1, report.Failed)
+	assert.Equal(t, []Result{
+		{Key: 1, Status: StatusSucceeded},
+		{Key: 2, Status: StatusFailed, Error: "not found"},
+		{Key: 3, Status: StatusSucceeded},
+	}, report.Results)
+	require.Len(t, progress, 3)
+	assert.Equal(t, Progress{Total: 3, Done: 3, Succeeded: 2, Failed: 1}, progress[2])
+}
+
+func TestRunBoundsConcurrency(t *testing.T) {
+	var running, maxRunning int32
+	operation := func(context.Context, int64) error {
+		current := atomic.AddInt32(&running, 1)
+		for {
+			previous := atomic.LoadInt32(&maxRunning)
+			if current <= previous || atomic.CompareAndSwapInt32(&maxRunning, previous, current) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		atomic.AddInt32(&running, -1)
+		return nil
+	}
+
+	report, err := Run(context.Background(), make([]int64, 20), operation, Options{Concurrency: 3})
+
+	require.NoError(t, err)
+	assert.Equal(t, 20, report.Succeeded)
+	assert.LessOrEqual(t, maxRunning, int32(3))
+}
+
+func TestRunLimitsRate(t *testing.T) {
+	start := time.Now()
+
+	_, err := Run(context.Background(), []int64{1, 2, 3, 4, 5}, func(context.Context, int64) error { return nil }, Options{RateLimit: 100})
+
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, time.Since(start), 40*time.Millisecond)
+}
+
+func TestRunReportsCanceledKeys(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	operation := func(_ context.Context, key int64) error {
+		if key == 1 {
+			cancel()
+		}
+		return nil
+	}
+
+	report, err := Run(ctx, []int64{1, 2, 3}, operation, Options{Concurrency: 1})
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Succeeded)
+	assert.Equal(t, 2, report.Canceled)
+	assert.Equal(t, Result{Key: 3, Status: StatusCanceled}, report.Results[2])
+}
+
+func TestRunResumesFromCheckpoint(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checkpoint")
+
+	checkpoint, err := OpenCheckpoint(path)
+	require.NoError(t, err)
+	failing := func(_ context.Context, key int64) error {
+		if key == 2 {
+			return errors.New("unavailable")
+		}
+		return nil
+	}
+	_, err = Run(context.Background(), []int64{1, 2, 3}, failing, Options{Checkpoint: checkpoint})
+	require.NoError(t, err)
+	require.NoError(t, checkpoint.Close())
+
+	checkpoint, err = OpenCheckpoint(path)
+	require.NoError(t, err)
+	defer checkpoint.Close()
+
+	var processed []int64
+	report, err := Run(context.Background(), []int64{1, 2, 3}, func(_ context.Context, key int64) error {
+		processed = append(processed, key)
+		return nil
+	}, Options{Checkpoint: checkpoint})
+
+	require.NoError(t, err)
+	assert.Equal(t, []int64{2}, processed)
+	assert.Equal(t, 2, report.Skipped)
+	assert.Equal(t, 1, report.Succeeded)
+	assert.True(t, checkpoint.Contains(2))
+}
@@ -0,0 +1,142 @@
+// Copyright © 2018 Camunda Services GmbH (info@camunda.com)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulk
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ReadKeys reads keys separated by whitespace or commas, ignoring the rest of lines after a '#'. Lines may be of any
+// length, e.g. a single line listing all keys.
+func ReadKeys(reader io.Reader) ([]int64, error) {
+	var (
+		keys    []int64
+		field   strings.Builder
+		line    = 1
+		comment = false
+	)
+
+	// parseField appends the key of the field read so far, if any
+	parseField := func() error {
+		if field.Len() == 0 {
+			return nil
+		}
+
+		key, err := strconv.ParseInt(field.String(), 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid key %q on line %d: %w", field.String(), line, err)
+		}
+		keys = append(keys, key)
+		field.Reset()
+		return nil
+	}
+
+	buffered := bufio.NewReader(reader)
+	for {
+		b, err := buffered.ReadByte()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case b == '\n':
+			err = parseField()
+			line++
+			comment = false
+		case comment:
+		case b == '#':
+			err = parseField()
+			comment = true
+		case b == ',' || b == ' ' || b == '\t' || b == '\r':
+			err = parseField()
+		default:
+			field.WriteByte(b)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := parseField(); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// Checkpoint records the keys processed successfully in a file, one key per line, so that an interrupted run can be
+// resumed by skipping them
+type Checkpoint struct {
+	mutex sync.Mutex
+	file  *os.File
+	keys  map[int64]struct{}
+}
+
+// OpenCheckpoint opens the checkpoint file at path, creating it if it does not exist, and loads its keys
+func OpenCheckpoint(path string) (*Checkpoint, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, err
+	}
+
+	keys, err := ReadKeys(file)
+	if err != nil {
+		_ = file.Close()
+		return nil, fmt.Errorf("failed to read checkpoint %s: %w", path, err)
+	}
+
+	checkpoint := &Checkpoint{file: file, keys: make(map[int64]struct{}, len(keys))}
+	for _, key := range keys {
+		checkpoint.keys[key] = struct{}{}
+	}
+	return checkpoint, nil
+}
+
+// Contains returns true if the key was recorded
+func (checkpoint *Checkpoint) Contains(key int64) bool {
+	checkpoint.mutex.Lock()
+	defer checkpoint.mutex.Unlock()
+
+	_, ok := checkpoint.keys[key]
+	return ok
+}
+
+// Record adds the key to the checkpoint file
+func (checkpoint *Checkpoint) Record(key int64) error {
+	checkpoint.mutex.Lock()
+	defer checkpoint.mutex.Unlock()
+
+	if _, ok := checkpoint.keys[key]; ok {
+		return nil
+	}
+	if _, err := fmt.Fprintln(checkpoint.file, key); err != nil {
+		return err
+	}
+	checkpoint.keys[key] = struct{}{}
+	return nil
+}
+
+// Close closes the checkpoint file
+func (checkpoint *Checkpoint) Close() error {
+	return checkpoint.file.Close()
+}
@@ -0,0 +1,51 @@
+// Copyright © 2018 Camunda Services GmbH (info@camunda.com)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulk
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadKeys(t *testing.T) {
+	keys, err := ReadKeys(strings.NewReader("1\n2, 3\n\n# comment\n4 5 # trailing\r\n"))
+
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1, 2, 3, 4, 5}, keys)
+}
+
+func TestReadKeysRejectsInvalidKeys(t *testing.T) {
+	_, err := ReadKeys(strings.NewReader("1\nfoo\n"))
+
+	assert.EqualError(t, err, `invalid key "foo" on line 2: strconv.ParseInt: parsing "foo": invalid syntax`)
+}
+
+func TestReadKeysFromLongLine(t *testing.T) {
+	var line strings.Builder
+	var want []int64
+	for key := int64(2251799813685248); key < 2251799813685248+20000; key++ {
+		line.WriteString(strconv.FormatInt(key, 10) + ",")
+		want = append(want, key)
+	}
+
+	keys, err := ReadKeys(strings.NewReader(line.String()))
+
+	require.NoError(t, err)
+	assert.Equal(t, want, keys)
+}
@@ -0,0 +1,66 @@
+// Copyright © 2018 Camunda Services GmbH (info@camunda.com)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulk
+
+import (
+	"context"
+
+	"github.com/camunda-community-hub/zeebe-client-go/v8/pkg/commands"
+)
+
+// Client creates the commands of the bulk operations, it is implemented by zbc.Client
+type Client interface {
+	NewCancelInstanceCommand() commands.CancelInstanceStep1
+	NewResolveIncidentCommand() commands.ResolveIncidentCommandStep1
+	NewUpdateJobRetriesCommand() commands.UpdateJobRetriesCommandStep1
+	NewSetVariablesCommand() commands.SetVariablesCommandStep1
+}
+
+// CancelInstance returns an operation cancelling the process instance with the key
+func CancelInstance(client Client) Operation {
+	return func(ctx context.Context, key int64) error {
+		_, err := client.NewCancelInstanceCommand().ProcessInstanceKey(key).Send(ctx)
+		return err
+	}
+}
+
+// ResolveIncident returns an operation resolving the incident with the key
+func ResolveIncident(client Client) Operation {
+	return func(ctx context.Context, key int64) error {
+		_, err := client.NewResolveIncidentCommand().IncidentKey(key).Send(ctx)
+		return err
+	}
+}
+
+// UpdateJobRetries returns an operation setting the retries of the job with the key
+func UpdateJobRetries(client Client, retries int32) Operation {
+	return func(ctx context.Context, key int64) error {
+		_, err := client.NewUpdateJobRetriesCommand().JobKey(key).Retries(retries).Send(ctx)
+		return err
+	}
+}
+
+// SetVariables returns an operation setting the variables, given as JSON document, of the element instance with the
+// key. Local variables are set in the scope of the element instance only.
+func SetVariables(client Client, variables string, local bool) Operation {
+	return func(ctx context.Context, key int64) error {
+		command, err := client.NewSetVariablesCommand().ElementInstanceKey(key).VariablesFromString(variables)
+		if err != nil {
+			return err
+		}
+		_, err = command.Local(local).Send(ctx)
+		return err
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright © 2018 Camunda Services GmbH (info@camunda.com)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulk
+
+import (
+	"context"
+	"testing"
+
+	"github.com/camunda-community-hub/zeebe-client-go/v8/internal/mock_pb"
+	"github.com/camunda-community-hub/zeebe-client-go/v8/internal/utils"
+	"github.com/camunda-community-hub/zeebe-client-go/v8/pkg/commands"
+	"github.com/camunda-community-hub/zeebe-client-go/v8/pkg/pb"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+type testClient struct {
+	gateway pb.GatewayClient
+}
+
+func noRetry(context.Context, error) bool {
+	return false
+}
+
+func (c testClient) NewCancelInstanceCommand() commands.CancelInstanceStep1 {
+	return commands.NewCancelInstanceCommand(c.gateway, noRetry)
+}
+
+func (c testClient) NewResolveIncidentCommand() commands.ResolveIncidentCommandStep1 {
+	return commands.NewResolveIncidentCommand(c.gateway, noRetry)
+}
+
+func (c testClient) NewUpdateJobRetriesCommand() commands.UpdateJobRetriesCommandStep1 {
+	return commands.NewUpdateJobRetriesCommand(c.gateway, noRetry)
+}
+
+func (c testClient) NewSetVariablesCommand() commands.SetVariablesCommandStep1 {
+	return commands.NewSetVariablesCommand(c.gateway, noRetry)
+}
+
+func TestOperations(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gateway := mock_pb.NewMockGatewayClient(ctrl)
+	client := testClient{gateway: gateway}
+
+	gateway.EXPECT().CancelProcessInstance(gomock.Any(), &utils.RPCTestMsg{Msg: &pb.CancelProcessInstanceRequest{ProcessInstanceKey: 1}}).
+		Return(&pb.CancelProcessInstanceResponse{}, nil)
+	gateway.EXPECT().ResolveIncident(gomock.Any(), &utils.RPCTestMsg{Msg: &pb.ResolveIncidentRequest{IncidentKey: 2}}).
+		Return(&pb.ResolveIncidentResponse{}, nil)
+	gateway.EXPECT().UpdateJobRetries(gomock.Any(), &utils.RPCTestMsg{Msg: &pb.UpdateJobRetriesRequest{JobKey: 3, Retries: 5}}).
+		Return(&pb.UpdateJobRetriesResponse{}, nil)
+	gateway.EXPECT().SetVariables(gomock.Any(), &utils.RPCTestMsg{Msg: &pb.SetVariablesRequest{ElementInstanceKey: 4, Variables: `{"foo":"bar"}`, Local: true}}).
+		Return(&pb.SetVariablesResponse{}, nil)
+
+	ctx := context.Background()
+	assert.NoError(t, CancelInstance(client)(ctx, 1))
+	assert.NoError(t, ResolveIncident(client)(ctx, 2))
+	assert.NoError(t, UpdateJobRetries(client, 5)(ctx, 3))
+	assert.NoError(t, SetVariables(client, `{"foo":"bar"}`, true)(ctx, 4))
+}
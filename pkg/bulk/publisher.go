@@ -0,0 +1,242 @@
+// Copyright © 2018 Camunda Services GmbH (info@camunda.com)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulk
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/camunda-community-hub/zeebe-client-go/v8/pkg/commands"
+	zberrors "github.com/camunda-community-hub/zeebe-client-go/v8/pkg/errors"
+	"github.com/camunda-community-hub/zeebe-client-go/v8/pkg/worker"
+)
+
+const DefaultPublishMaxAttempts = 10
+
+// Message is a message published by a MessagePublisher
+type Message struct {
+	Name           string
+	CorrelationKey string
+	// ID is the message ID, a message is published only once per ID within its time to live. It is derived with the
+	// MessageID function of the publisher if empty.
+	ID         string
+	TimeToLive time.Duration
+	TenantID   string
+	// Variables must be JSON serializable, nil for no variables
+	Variables interface{}
+}
+
+// MessageClient creates the commands publishing messages, it is implemented by zbc.Client
+type MessageClient interface {
+	NewPublishMessageCommand() commands.PublishMessageCommandStep1
+}
+
+// PublishStatus is the outcome of publishing a message
+type PublishStatus string
+
+const (
+	PublishStatusPublished PublishStatus = "published"
+	// PublishStatusDuplicate is the status of messages whose ID was already published, which counts as success
+	PublishStatusDuplicate PublishStatus = "duplicate"
+	PublishStatusFailed    PublishStatus = "failed"
+)
+
+// PublishResult is the outcome of publishing one message
+type PublishResult struct {
+	MessageID string
+	// Key is the key of the published message, zero unless the status is PublishStatusPublished
+	Key      int64
+	Status   PublishStatus
+	Attempts int
+	Err      error
+}
+
+// Succeeded returns true if the message was published, now or before
+func (result PublishResult) Succeeded() bool {
+	return result.Status != PublishStatusFailed
+}
+
+// PublisherOptions configure a MessagePublisher
+type PublisherOptions struct {
+	// MessageID derives the ID of the messages without one from the message, e.g. from the key of the event it
+	// forwards. Deriving the ID deterministically makes publishing a batch again, after a partial failure, safe.
+	MessageID func(Message) string
+	// Concurrency is the maximum number of messages published at the same time, defaults to DefaultConcurrency
+	Concurrency int
+	// RequestTimeout is the timeout of each publish request, defaults to DefaultRequestTimeout
+	RequestTimeout time.Duration
+	// MaxAttempts is the maximum number of attempts of a message rejected because of back pressure, defaults to
+	// DefaultPublishMaxAttempts
+	MaxAttempts int
+	// Backoff supplies the delay during which publishing pauses after a rejection because of back pressure, defaults
+	// to an exponential backoff
+	Backoff worker.BackoffSupplier
+}
+
+// MessagePublisher publishes batches of messages. Messages already published with the same ID count as success, and
+// when the gateway rejects a message because of back pressure, all publishing pauses for a backoff delay before the
+// message is retried.
+type MessagePublisher struct {
+	client  MessageClient
+	options PublisherOptions
+
+	mutex      sync.Mutex
+	delay      time.Duration
+	pauseUntil time.Time
+}
+
+func NewMessagePublisher(client MessageClient, options PublisherOptions) *MessagePublisher {
+	if options.Concurrency <= 0 {
+		options.Concurrency = DefaultConcurrency
+	}
+	if options.RequestTimeout <= 0 {
+		options.RequestTimeout = DefaultRequestTimeout
+	}
+	if options.MaxAttempts <= 0 {
+		options.MaxAttempts = DefaultPublishMaxAttempts
+	}
+	if options.Backoff == nil {
+		options.Backoff = worker.NewExponentialBackoffBuilder().Build()
+	}
+
+	return &MessagePublisher{client: client, options: options}
+}
+
+// Publish publishes the messages and returns their results in the order of the messages. Messages not published
+// when ctx is done fail with the error of ctx.
+func (publisher *MessagePublisher) Publish(ctx context.Context, messages []Message) []PublishResult {
+	results := make([]PublishResult, len(messages))
+	slots := make(chan struct{}, publisher.options.Concurrency)
+
+	var publishing sync.WaitGroup
+	for i, message := range messages {
+		if message.ID == "" && publisher.options.MessageID != nil {
+			message.ID = publisher.options.MessageID(message)
+		}
+
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+			results[i] = PublishResult{MessageID: message.ID, Status: PublishStatusFailed, Err: ctx.Err()}
+			continue
+		}
+
+		publishing.Add(1)
+		go func(i int, message Message) {
+			defer publishing.Done()
+			defer func() { <-slots }()
+
+			results[i] = publisher.publish(ctx, message)
+		}(i, message)
+	}
+	publishing.Wait()
+
+	return results
+}
+
+func (publisher *MessagePublisher) publish(ctx context.Context, message Message) PublishResult {
+	result := PublishResult{MessageID: message.ID}
+
+	for {
+		if err := publisher.awaitPause(ctx); err != nil {
+			result.Status, result.Err = PublishStatusFailed, err
+			return result
+		}
+
+		result.Attempts++
+		key, err := publisher.send(ctx, message)
+		switch {
+		case err == nil:
+			publisher.resetBackoff()
+			result.Status, result.Key = PublishStatusPublished, key
+			return result
+		case errors.Is(err, zberrors.ErrAlreadyExists):
+			publisher.resetBackoff()
+			result.Status = PublishStatusDuplicate
+			return result
+		case errors.Is(err, zberrors.ErrResourceExhausted) && result.Attempts < publisher.options.MaxAttempts:
+			publisher.backoff()
+		default:
+			result.Status, result.Err = PublishStatusFailed, err
+			return result
+		}
+	}
+}
+
+func (publisher *MessagePublisher) send(ctx context.Context, message Message) (int64, error) {
+	command := publisher.client.NewPublishMessageCommand().
+		MessageName(message.Name).
+		CorrelationKey(message.CorrelationKey).
+		MessageId(message.ID).
+		TenantId(message.TenantID)
+	if message.TimeToLive > 0 {
+		command = command.TimeToLive(message.TimeToLive)
+	}
+	if message.Variables != nil {
+		var err error
+		if command, err = command.VariablesFromObject(message.Variables); err != nil {
+			return 0, err
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, publisher.options.RequestTimeout)
+	defer cancel()
+
+	response, err := command.Send(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return response.GetKey(), nil
+}
+
+// awaitPause blocks until the pause after a rejection because of back pressure is over
+func (publisher *MessagePublisher) awaitPause(ctx context.Context) error {
+	publisher.mutex.Lock()
+	wait := time.Until(publisher.pauseUntil)
+	publisher.mutex.Unlock()
+
+	if wait <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (publisher *MessagePublisher) backoff() {
+	publisher.mutex.Lock()
+	defer publisher.mutex.Unlock()
+
+	publisher.delay = publisher.options.Backoff.SupplyRetryDelay(publisher.delay)
+	if pauseUntil := time.Now().Add(publisher.delay); pauseUntil.After(publisher.pauseUntil) {
+		publisher.pauseUntil = pauseUntil
+	}
+}
+
+func (publisher *MessagePublisher) resetBackoff() {
+	publisher.mutex.Lock()
+	defer publisher.mutex.Unlock()
+
+	publisher.delay = 0
+}
@@ -0,0 +1,120 @@
+// Copyright © 2018 Camunda Services GmbH (info@camunda.com)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulk
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/camunda-community-hub/zeebe-client-go/v8/internal/mock_pb"
+	"github.com/camunda-community-hub/zeebe-client-go/v8/internal/utils"
+	"github.com/camunda-community-hub/zeebe-client-go/v8/pkg/commands"
+	"github.com/camunda-community-hub/zeebe-client-go/v8/pkg/pb"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (c testClient) NewPublishMessageCommand() commands.PublishMessageCommandStep1 {
+	return commands.NewPublishMessageCommand(c.gateway, noRetry)
+}
+
+type constantBackoff time.Duration
+
+func (b constantBackoff) SupplyRetryDelay(time.Duration) time.Duration {
+	return time.Duration(b)
+}
+
+func TestMessagePublisher(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gateway := mock_pb.NewMockGatewayClient(ctrl)
+	gateway.EXPECT().PublishMessage(gomock.Any(), &utils.RPCTestMsg{Msg: &pb.PublishMessageRequest{
+		Name:           "order-paid",
+		CorrelationKey: "order-1",
+		MessageId:      "event-1",
+		TimeToLive:     60000,
+		Variables:      `{"amount":12}`,
+	}}).Return(&pb.PublishMessageResponse{Key: 1}, nil)
+	gateway.EXPECT().PublishMessage(gomock.Any(), &utils.RPCTestMsg{Msg: &pb.PublishMessageRequest{
+		Name:           "order-paid",
+		CorrelationKey: "order-2",
+		MessageId:      "event-2",
+	}}).Return(nil, status.Error(codes.AlreadyExists, "message already published"))
+	gateway.EXPECT().PublishMessage(gomock.Any(), &utils.RPCTestMsg{Msg: &pb.PublishMessageRequest{
+		Name:           "order-paid",
+		CorrelationKey: "order-3",
+		MessageId:      "event-3",
+	}}).Return(nil, status.Error(codes.InvalidArgument, "invalid"))
+
+	publisher := NewMessagePublisher(testClient{gateway: gateway}, PublisherOptions{
+		MessageID: func(message Message) string {
+			return "event-" + message.CorrelationKey[len("order-"):]
+		},
+	})
+
+	results := publisher.Publish(context.Background(), []Message{
+		{Name: "order-paid", CorrelationKey: "order-1", TimeToLive: time.Minute, Variables: map[string]interface{}{"amount": 12}},
+		{Name: "order-paid", CorrelationKey: "order-2"},
+		{Name: "order-paid", CorrelationKey: "order-3"},
+	})
+
+	require.Len(t, results, 3)
+	assert.Equal(t, PublishResult{MessageID: "event-1", Key: 1, Status: PublishStatusPublished, Attempts: 1}, results[0])
+	assert.Equal(t, PublishResult{MessageID: "event-2", Status: PublishStatusDuplicate, Attempts: 1}, results[1])
+	assert.True(t, results[1].Succeeded())
+	assert.Equal(t, PublishStatusFailed, results[2].Status)
+	assert.Equal(t, codes.InvalidArgument, status.Code(results[2].Err))
+	assert.False(t, results[2].Succeeded())
+}
+
+func TestMessagePublisherRetriesOnBackPressure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gateway := mock_pb.NewMockGatewayClient(ctrl)
+	gomock.InOrder(
+		gateway.EXPECT().PublishMessage(gomock.Any(), gomock.Any()).Return(nil, status.Error(codes.ResourceExhausted, "back pressure")).Times(2),
+		gateway.EXPECT().PublishMessage(gomock.Any(), gomock.Any()).Return(&pb.PublishMessageResponse{Key: 1}, nil),
+	)
+
+	publisher := NewMessagePublisher(testClient{gateway: gateway}, PublisherOptions{Backoff: constantBackoff(10 * time.Millisecond)})
+
+	start := time.Now()
+	results := publisher.Publish(context.Background(), []Message{{Name: "foo", CorrelationKey: "bar", ID: "1"}})
+
+	assert.Equal(t, PublishResult{MessageID: "1", Key: 1, Status: PublishStatusPublished, Attempts: 3}, results[0])
+	assert.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond)
+}
+
+func TestMessagePublisherGivesUpAfterMaxAttempts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gateway := mock_pb.NewMockGatewayClient(ctrl)
+	gateway.EXPECT().PublishMessage(gomock.Any(), gomock.Any()).Return(nil, status.Error(codes.ResourceExhausted, "back pressure")).Times(2)
+
+	publisher := NewMessagePublisher(testClient{gateway: gateway}, PublisherOptions{MaxAttempts: 2, Backoff: constantBackoff(time.Millisecond)})
+
+	results := publisher.Publish(context.Background(), []Message{{Name: "foo", CorrelationKey: "bar"}})
+
+	assert.Equal(t, PublishStatusFailed, results[0].Status)
+	assert.Equal(t, 2, results[0].Attempts)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(results[0].Err))
+}
@@ -21,6 +21,7 @@ import (
 	"time"
 
 	"github.com/camunda-community-hub/zeebe-client-go/v8/pkg/entities"
+	zberrors "github.com/camunda-community-hub/zeebe-client-go/v8/pkg/errors"
 	"github.com/camunda-community-hub/zeebe-client-go/v8/pkg/pb"
 )
 
@@ -92,7 +93,7 @@ func (cmd *ActivateJobsCommand) Send(ctx context.Context) ([]entities.Job, error
 
 	stream, err := cmd.openStream(ctx)
 	if err != nil {
-		return nil, err
+		return nil, zberrors.Wrap(err)
 	}
 
 	var activatedJobs []entities.Job
@@ -111,7 +112,7 @@ func (cmd *ActivateJobsCommand) Send(ctx context.Context) ([]entities.Job, error
 		}
 
 		if err != nil {
-			return activatedJobs, err
+			return activatedJobs, zberrors.Wrap(err)
 		}
 		for _, activatedJob := range response.Jobs {
 			activatedJobs = append(activatedJobs, entities.Job{ActivatedJob: activatedJob})
@@ -132,15 +133,12 @@ func (cmd *ActivateJobsCommand) openStream(ctx context.Context) (pb.Gateway_Acti
 
 	return stream, nil
 }
-func NewActivateJobsCommand(gateway pb.GatewayClient, pred retryPredicate) ActivateJobsCommandStep1 {
+func NewActivateJobsCommand(gateway pb.GatewayClient, pred retryPredicate, opts ...CommandOption) ActivateJobsCommandStep1 {
 	return &ActivateJobsCommand{
 		request: pb.ActivateJobsRequest{
 			Timeout: DefaultJobTimeoutInMs,
 			Worker:  DefaultJobWorkerName,
 		},
-		Command: Command{
-			gateway:     gateway,
-			shouldRetry: pred,
-		},
+		Command: newCommand(gateway, pred, opts),
 	}
 }
@@ -18,7 +18,7 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/camunda-community-hub/zeebe-client-go/v8/internal/utils"
+	zberrors "github.com/camunda-community-hub/zeebe-client-go/v8/pkg/errors"
 	"github.com/camunda-community-hub/zeebe-client-go/v8/pkg/pb"
 )
 
@@ -58,7 +58,7 @@ func (cmd *BroadcastSignalCommand) TenantId(tenantId string) BroadcastSignalComm
 }
 
 func (cmd *BroadcastSignalCommand) VariablesFromString(variables string) (BroadcastSignalCommandStep2, error) {
-	err := cmd.mixin.Validate("variables", variables)
+	variables, err := cmd.validateVariables("variables", variables)
 	if err != nil {
 		return nil, err
 	}
@@ -72,7 +72,7 @@ func (cmd *BroadcastSignalCommand) VariablesFromStringer(variables fmt.Stringer)
 }
 
 func (cmd *BroadcastSignalCommand) VariablesFromObject(variables interface{}) (BroadcastSignalCommandStep2, error) {
-	value, err := cmd.mixin.AsJSON("variables", variables, false)
+	value, err := cmd.variablesAsJSON("variables", variables, false)
 	if err != nil {
 		return nil, err
 	}
@@ -82,7 +82,7 @@ func (cmd *BroadcastSignalCommand) VariablesFromObject(variables interface{}) (B
 }
 
 func (cmd *BroadcastSignalCommand) VariablesFromObjectIgnoreOmitempty(variables interface{}) (BroadcastSignalCommandStep2, error) {
-	value, err := cmd.mixin.AsJSON("variables", variables, true)
+	value, err := cmd.variablesAsJSON("variables", variables, true)
 	if err != nil {
 		return nil, err
 	}
@@ -101,20 +101,20 @@ func (cmd *BroadcastSignalCommand) SignalName(signalName string) BroadcastSignal
 }
 
 func (cmd *BroadcastSignalCommand) Send(ctx context.Context) (*pb.BroadcastSignalResponse, error) {
+	if err := cmd.offloadVariables(ctx, "variables", &cmd.request.Variables); err != nil {
+		return nil, err
+	}
+
 	response, err := cmd.gateway.BroadcastSignal(ctx, &cmd.request)
 	if cmd.shouldRetry(ctx, err) {
 		return cmd.Send(ctx)
 	}
 
-	return response, err
+	return response, zberrors.Wrap(err)
 }
 
-func NewBroadcastSignalCommand(gateway pb.GatewayClient, pred retryPredicate) BroadcastSignalCommandStep1 {
+func NewBroadcastSignalCommand(gateway pb.GatewayClient, pred retryPredicate, opts ...CommandOption) BroadcastSignalCommandStep1 {
 	return &BroadcastSignalCommand{
-		Command: Command{
-			mixin:       utils.NewJSONStringSerializer(),
-			gateway:     gateway,
-			shouldRetry: pred,
-		},
+		Command: newCommand(gateway, pred, opts),
 	}
 }
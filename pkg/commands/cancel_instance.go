@@ -17,6 +17,7 @@ package commands
 import (
 	"context"
 
+	zberrors "github.com/camunda-community-hub/zeebe-client-go/v8/pkg/errors"
 	"github.com/camunda-community-hub/zeebe-client-go/v8/pkg/pb"
 )
 
@@ -39,7 +40,7 @@ func (cmd *CancelProcessInstanceCommand) Send(ctx context.Context) (*pb.CancelPr
 		return cmd.Send(ctx)
 	}
 
-	return response, err
+	return response, zberrors.Wrap(err)
 }
 
 func (cmd *CancelProcessInstanceCommand) ProcessInstanceKey(key int64) DispatchCancelProcessInstanceCommand {
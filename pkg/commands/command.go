@@ -16,9 +16,11 @@ package commands
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/camunda-community-hub/zeebe-client-go/v8/internal/utils"
+	"github.com/camunda-community-hub/zeebe-client-go/v8/pkg/entities"
 	"github.com/camunda-community-hub/zeebe-client-go/v8/pkg/pb"
 )
 
@@ -30,12 +32,152 @@ const (
 type retryPredicate func(context.Context, error) bool
 
 type Command struct {
-	mixin utils.SerializerMixin
+	mixin            utils.SerializerMixin
+	serializer       entities.Serializer
+	claimCheck       *entities.ClaimCheck
+	maxVariablesSize int
+	validator        func(variables string) error
+	offloaded        bool
 
 	gateway     pb.GatewayClient
 	shouldRetry retryPredicate
 }
 
+// CommandOption configures a command when it is created
+type CommandOption func(*Command)
+
+// WithSerializer makes the VariablesFromObject and VariablesFromMap methods of the command encode variables with the
+// given serializer instead of entities.JSONSerializer. A nil serializer is ignored.
+func WithSerializer(serializer entities.Serializer) CommandOption {
+	return func(cmd *Command) {
+		if serializer != nil {
+			cmd.serializer = serializer
+		}
+	}
+}
+
+// WithClaimCheck makes the command offload large variables to the blob store of the claim check when it is sent. A nil
+// claim check is ignored.
+func WithClaimCheck(claimCheck *entities.ClaimCheck) CommandOption {
+	return func(cmd *Command) {
+		if claimCheck != nil {
+			cmd.claimCheck = claimCheck
+		}
+	}
+}
+
+// WithMaxVariablesSize makes the VariablesFrom* methods of the command fail with a *VariablesTooLargeError if the JSON
+// document of the variables is larger than maxSize bytes. With a claim check, the size is checked by Send instead,
+// after offloading large variables. This avoids a round trip to the gateway for variables the broker would reject.
+// Zero or less means no limit.
+func WithMaxVariablesSize(maxSize int) CommandOption {
+	return func(cmd *Command) {
+		cmd.maxVariablesSize = maxSize
+	}
+}
+
+// WithVariablesValidator makes the VariablesFrom* methods of the command fail with the error returned by the
+// validator, which is given the variables as JSON document before large variables are offloaded. Commands completing
+// jobs also validate their variables when they are sent without any.
+func WithVariablesValidator(validator func(variables string) error) CommandOption {
+	return func(cmd *Command) {
+		cmd.validator = validator
+	}
+}
+
+// VariablesTooLargeError is returned when variables are larger than the limit set with WithMaxVariablesSize
+type VariablesTooLargeError struct {
+	Parameter string
+	Size      int
+	Limit     int
+}
+
+func (e *VariablesTooLargeError) Error() string {
+	return fmt.Sprintf("parameter %q is %d bytes, which exceeds the limit of %d bytes", e.Parameter, e.Size, e.Limit)
+}
+
+func newCommand(gateway pb.GatewayClient, pred retryPredicate, opts []CommandOption) Command {
+	cmd := Command{
+		gateway:     gateway,
+		shouldRetry: pred,
+	}
+
+	for _, opt := range opts {
+		opt(&cmd)
+	}
+
+	if cmd.serializer == nil {
+		cmd.mixin = utils.NewJSONStringSerializer()
+	} else {
+		cmd.mixin = utils.NewSerializerMixin(cmd.serializer)
+	}
+	return cmd
+}
+
+// validateVariables checks that the variables are a JSON object, and prepares them to be sent
+func (cmd *Command) validateVariables(name string, variables string) (string, error) {
+	if err := cmd.mixin.Validate(name, variables); err != nil {
+		return "", err
+	}
+
+	return cmd.prepareVariables(name, variables)
+}
+
+// variablesAsJSON serializes the variables to a JSON object, and prepares them to be sent
+func (cmd *Command) variablesAsJSON(name string, variables interface{}, ignoreOmitempty bool) (string, error) {
+	value, err := cmd.mixin.AsJSON(name, variables, ignoreOmitempty)
+	if err != nil {
+		return "", err
+	}
+
+	return cmd.prepareVariables(name, value)
+}
+
+// prepareVariables validates the variables, and checks their size unless large ones are offloaded when the command is
+// sent, see offloadVariables
+func (cmd *Command) prepareVariables(name string, variables string) (string, error) {
+	if cmd.validator != nil {
+		if err := cmd.validator(variables); err != nil {
+			return "", err
+		}
+	}
+
+	if cmd.claimCheck == nil {
+		return variables, cmd.checkVariablesSize(name, variables)
+	}
+
+	return variables, nil
+}
+
+// offloadVariables offloads the large variables to the claim check store, if any, with the context the command is sent
+// with, and checks the size of the remaining ones. Variables are offloaded once, even if the command is sent again.
+func (cmd *Command) offloadVariables(ctx context.Context, name string, variables *string) error {
+	if cmd.claimCheck == nil || cmd.offloaded {
+		return nil
+	}
+
+	offloaded, err := cmd.claimCheck.Offload(ctx, *variables)
+	if err != nil {
+		return err
+	}
+
+	if err := cmd.checkVariablesSize(name, offloaded); err != nil {
+		return err
+	}
+
+	*variables = offloaded
+	cmd.offloaded = true
+	return nil
+}
+
+func (cmd *Command) checkVariablesSize(name string, variables string) error {
+	if cmd.maxVariablesSize > 0 && len(variables) > cmd.maxVariablesSize {
+		return &VariablesTooLargeError{Parameter: name, Size: len(variables), Limit: cmd.maxVariablesSize}
+	}
+
+	return nil
+}
+
 func getLongPollingMillis(ctx context.Context) int64 {
 	longPollMillis := int64(-1)
 
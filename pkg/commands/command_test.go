@@ -0,0 +1,129 @@
+// Copyright © 2018 Camunda Services GmbH (info@camunda.com)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/camunda-community-hub/zeebe-client-go/v8/internal/mock_pb"
+	"github.com/camunda-community-hub/zeebe-client-go/v8/internal/utils"
+	"github.com/camunda-community-hub/zeebe-client-go/v8/pkg/entities"
+	"github.com/camunda-community-hub/zeebe-client-go/v8/pkg/pb"
+	"github.com/golang/mock/gomock"
+)
+
+type testBlobStore struct {
+	values []string
+}
+
+func (s *testBlobStore) Put(_ context.Context, value []byte) (string, error) {
+	s.values = append(s.values, string(value))
+	return "blob", nil
+}
+
+func (s *testBlobStore) Get(context.Context, string) ([]byte, error) {
+	return nil, errors.New("not implemented")
+}
+
+func TestVariablesFromStringFailsAboveMaxVariablesSize(t *testing.T) {
+	noRetry := func(context.Context, error) bool { return false }
+	variables := `{"foo":"0123456789"}`
+
+	setVariables := func(opts ...CommandOption) []error {
+		_, completeErr := NewCompleteJobCommand(nil, noRetry, opts...).JobKey(1).VariablesFromString(variables)
+		_, failErr := NewFailJobCommand(nil, noRetry, opts...).JobKey(1).Retries(1).VariablesFromString(variables)
+		_, throwErr := NewThrowErrorCommand(nil, noRetry, opts...).JobKey(1).ErrorCode("code").VariablesFromString(variables)
+		_, setErr := NewSetVariablesCommand(nil, noRetry, opts...).ElementInstanceKey(1).VariablesFromString(variables)
+		_, publishErr := NewPublishMessageCommand(nil, noRetry, opts...).MessageName("msg").CorrelationKey("key").VariablesFromString(variables)
+		_, createErr := NewCreateInstanceCommand(nil, noRetry, opts...).ProcessDefinitionKey(1).VariablesFromString(variables)
+		_, evaluateErr := NewEvaluateDecisionCommand(nil, noRetry, opts...).DecisionKey(1).VariablesFromString(variables)
+		_, broadcastErr := NewBroadcastSignalCommand(nil, noRetry, opts...).SignalName("signal").VariablesFromString(variables)
+		return []error{completeErr, failErr, throwErr, setErr, publishErr, createErr, evaluateErr, broadcastErr}
+	}
+
+	for i, err := range setVariables(WithMaxVariablesSize(len(variables))) {
+		if err != nil {
+			t.Errorf("command %d: expected variables within the limit to be accepted, got %v", i, err)
+		}
+	}
+
+	for i, err := range setVariables(WithMaxVariablesSize(len(variables) - 1)) {
+		var tooLargeErr *VariablesTooLargeError
+		if !errors.As(err, &tooLargeErr) || tooLargeErr.Size != len(variables) || tooLargeErr.Limit != len(variables)-1 {
+			t.Errorf("command %d: expected VariablesTooLargeError, got %v", i, err)
+		}
+	}
+}
+
+func TestSendOffloadsLargeVariablesOnce(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock_pb.NewMockGatewayClient(ctrl)
+
+	request := &pb.CompleteJobRequest{
+		JobKey:    1,
+		Variables: `{"large":{"$claimCheck":"blob"},"small":1}`,
+	}
+	gomock.InOrder(
+		client.EXPECT().CompleteJob(gomock.Any(), &utils.RPCTestMsg{Msg: request}).Return(nil, errors.New("unavailable")),
+		client.EXPECT().CompleteJob(gomock.Any(), &utils.RPCTestMsg{Msg: request}).Return(&pb.CompleteJobResponse{}, nil),
+	)
+
+	store := &testBlobStore{}
+	retried := false
+	command := NewCompleteJobCommand(client, func(context.Context, error) bool {
+		retried = !retried
+		return retried
+	}, WithClaimCheck(&entities.ClaimCheck{Store: store, Threshold: 8}), WithMaxVariablesSize(42))
+
+	dispatch, err := command.JobKey(1).VariablesFromMap(map[string]interface{}{"small": 1, "large": "0123456789"})
+	if err != nil {
+		t.Fatalf("Failed to set variables: %v", err)
+	}
+
+	if len(store.values) != 0 {
+		t.Errorf("Expected variables to be offloaded when the command is sent, got %v", store.values)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), utils.DefaultTestTimeout)
+	defer cancel()
+
+	if _, err := dispatch.Send(ctx); err != nil {
+		t.Fatalf("Failed to send request: %v", err)
+	}
+
+	if len(store.values) != 1 || store.values[0] != `"0123456789"` {
+		t.Errorf("Expected large variable to be stored once, got %v", store.values)
+	}
+}
+
+func TestSendChecksSizeOfOffloadedVariables(t *testing.T) {
+	store := &testBlobStore{}
+	command := NewCompleteJobCommand(nil, func(context.Context, error) bool { return false },
+		WithClaimCheck(&entities.ClaimCheck{Store: store, Threshold: 8}), WithMaxVariablesSize(20))
+
+	dispatch, err := command.JobKey(1).VariablesFromMap(map[string]interface{}{"large": "0123456789"})
+	if err != nil {
+		t.Fatalf("Failed to set variables: %v", err)
+	}
+
+	var tooLargeErr *VariablesTooLargeError
+	if _, err := dispatch.Send(context.Background()); !errors.As(err, &tooLargeErr) {
+		t.Errorf("Expected VariablesTooLargeError, got %v", err)
+	}
+}
@@ -18,7 +18,7 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/camunda-community-hub/zeebe-client-go/v8/internal/utils"
+	zberrors "github.com/camunda-community-hub/zeebe-client-go/v8/pkg/errors"
 	"github.com/camunda-community-hub/zeebe-client-go/v8/pkg/pb"
 )
 
@@ -51,7 +51,7 @@ func (cmd *CompleteJobCommand) JobKey(jobKey int64) CompleteJobCommandStep2 {
 }
 
 func (cmd *CompleteJobCommand) VariablesFromString(variables string) (DispatchCompleteJobCommand, error) {
-	err := cmd.mixin.Validate("variables", variables)
+	variables, err := cmd.validateVariables("variables", variables)
 	if err != nil {
 		return nil, err
 	}
@@ -65,7 +65,7 @@ func (cmd *CompleteJobCommand) VariablesFromStringer(variables fmt.Stringer) (Di
 }
 
 func (cmd *CompleteJobCommand) VariablesFromObject(variables interface{}) (DispatchCompleteJobCommand, error) {
-	value, err := cmd.mixin.AsJSON("variables", variables, false)
+	value, err := cmd.variablesAsJSON("variables", variables, false)
 	if err != nil {
 		return nil, err
 	}
@@ -75,7 +75,7 @@ func (cmd *CompleteJobCommand) VariablesFromObject(variables interface{}) (Dispa
 }
 
 func (cmd *CompleteJobCommand) VariablesFromObjectIgnoreOmitempty(variables interface{}) (DispatchCompleteJobCommand, error) {
-	value, err := cmd.mixin.AsJSON("variables", variables, true)
+	value, err := cmd.variablesAsJSON("variables", variables, true)
 	if err != nil {
 		return nil, err
 	}
@@ -89,20 +89,26 @@ func (cmd *CompleteJobCommand) VariablesFromMap(variables map[string]interface{}
 }
 
 func (cmd *CompleteJobCommand) Send(ctx context.Context) (*pb.CompleteJobResponse, error) {
+	if err := cmd.offloadVariables(ctx, "variables", &cmd.request.Variables); err != nil {
+		return nil, err
+	}
+
+	if cmd.validator != nil && cmd.request.Variables == "" {
+		if err := cmd.validator("{}"); err != nil {
+			return nil, err
+		}
+	}
+
 	response, err := cmd.gateway.CompleteJob(ctx, &cmd.request)
 	if cmd.shouldRetry(ctx, err) {
 		return cmd.Send(ctx)
 	}
 
-	return response, err
+	return response, zberrors.Wrap(err)
 }
 
-func NewCompleteJobCommand(gateway pb.GatewayClient, pred retryPredicate) CompleteJobCommandStep1 {
+func NewCompleteJobCommand(gateway pb.GatewayClient, pred retryPredicate, opts ...CommandOption) CompleteJobCommandStep1 {
 	return &CompleteJobCommand{
-		Command: Command{
-			mixin:       utils.NewJSONStringSerializer(),
-			gateway:     gateway,
-			shouldRetry: pred,
-		},
+		Command: newCommand(gateway, pred, opts),
 	}
 }
@@ -16,12 +16,17 @@ package commands
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"testing"
 
 	"github.com/camunda-community-hub/zeebe-client-go/v8/internal/mock_pb"
 	"github.com/camunda-community-hub/zeebe-client-go/v8/internal/utils"
+	zberrors "github.com/camunda-community-hub/zeebe-client-go/v8/pkg/errors"
 	"github.com/camunda-community-hub/zeebe-client-go/v8/pkg/pb"
 	"github.com/golang/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func TestCompleteJobCommand(t *testing.T) {
@@ -278,3 +283,82 @@ func TestCompleteJobCommandWithVariablesFromMap(t *testing.T) {
 		t.Errorf("Failed to receive response")
 	}
 }
+
+func TestCompleteJobCommandWrapsGatewayErrors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock_pb.NewMockGatewayClient(ctrl)
+
+	request := &pb.CompleteJobRequest{
+		JobKey: 123,
+	}
+	gatewayErr := status.Error(codes.NotFound, "Expected to complete job with key '123', but no such job was found")
+
+	client.EXPECT().CompleteJob(gomock.Any(), &utils.RPCTestMsg{Msg: request}).Return(nil, gatewayErr)
+
+	ctx, cancel := context.WithTimeout(context.Background(), utils.DefaultTestTimeout)
+	defer cancel()
+
+	_, err := NewCompleteJobCommand(client, func(context.Context, error) bool {
+		return false
+	}).JobKey(123).Send(ctx)
+
+	if !errors.Is(err, zberrors.ErrNotFound) {
+		t.Errorf("Expected error to match ErrNotFound, but got %v", err)
+	}
+
+	if !errors.Is(err, gatewayErr) {
+		t.Errorf("Expected error to wrap the gateway error, but got %v", err)
+	}
+
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("Expected status code NotFound, but got %v", status.Code(err))
+	}
+}
+
+// customSerializer encodes every value as the same JSON document, to tell it apart from encoding/json
+type customSerializer struct{}
+
+func (customSerializer) Marshal(interface{}) ([]byte, error) {
+	return []byte(`{"encoded":"custom"}`), nil
+}
+
+func (customSerializer) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+func TestCompleteJobCommandWithSerializer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock_pb.NewMockGatewayClient(ctrl)
+
+	request := &pb.CompleteJobRequest{
+		JobKey:    123,
+		Variables: `{"encoded":"custom"}`,
+	}
+	stub := &pb.CompleteJobResponse{}
+
+	client.EXPECT().CompleteJob(gomock.Any(), &utils.RPCTestMsg{Msg: request}).Return(stub, nil)
+
+	command := NewCompleteJobCommand(client, func(context.Context, error) bool { return false }, WithSerializer(customSerializer{}))
+
+	variablesCommand, err := command.JobKey(123).VariablesFromObject(DataType{Foo: "bar"})
+	if err != nil {
+		t.Error("Failed to set variables: ", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), utils.DefaultTestTimeout)
+	defer cancel()
+
+	response, err := variablesCommand.Send(ctx)
+
+	if err != nil {
+		t.Errorf("Failed to send request")
+	}
+
+	if response != stub {
+		t.Errorf("Failed to receive response")
+	}
+}
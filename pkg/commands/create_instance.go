@@ -18,12 +18,19 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/camunda-community-hub/zeebe-client-go/v8/internal/utils"
+	zberrors "github.com/camunda-community-hub/zeebe-client-go/v8/pkg/errors"
 	"github.com/camunda-community-hub/zeebe-client-go/v8/pkg/pb"
 )
 
 const LatestVersion = -1
 
+// StartInstruction makes a process instance start before an element, instead of at the none start event of the
+// process. The gateway creates the variables of the command in the process scope, whatever the start instructions.
+type StartInstruction struct {
+	// ElementID is the id of the flow node to start before
+	ElementID string
+}
+
 type DispatchCreateInstanceCommand interface {
 	Send(context.Context) (*pb.CreateProcessInstanceResponse, error)
 }
@@ -58,6 +65,7 @@ type CreateInstanceCommandStep3 interface {
 	VariablesFromMap(map[string]interface{}) (CreateInstanceCommandStep3, error)
 
 	StartBeforeElement(string) CreateInstanceCommandStep3
+	StartInstructions(...StartInstruction) CreateInstanceCommandStep3
 
 	WithResult() CreateInstanceWithResultCommandStep1
 }
@@ -85,7 +93,7 @@ func (cmd *CreateInstanceCommand) TenantId(tenantId string) CreateInstanceComman
 }
 
 func (cmd *CreateInstanceCommand) VariablesFromString(variables string) (CreateInstanceCommandStep3, error) {
-	err := cmd.mixin.Validate("variables", variables)
+	variables, err := cmd.validateVariables("variables", variables)
 	if err != nil {
 		return nil, err
 	}
@@ -99,7 +107,7 @@ func (cmd *CreateInstanceCommand) VariablesFromStringer(variables fmt.Stringer)
 }
 
 func (cmd *CreateInstanceCommand) VariablesFromObject(variables interface{}) (CreateInstanceCommandStep3, error) {
-	value, err := cmd.mixin.AsJSON("variables", variables, false)
+	value, err := cmd.variablesAsJSON("variables", variables, false)
 	if err != nil {
 		return nil, err
 	}
@@ -109,7 +117,7 @@ func (cmd *CreateInstanceCommand) VariablesFromObject(variables interface{}) (Cr
 }
 
 func (cmd *CreateInstanceCommand) VariablesFromObjectIgnoreOmitempty(variables interface{}) (CreateInstanceCommandStep3, error) {
-	value, err := cmd.mixin.AsJSON("variables", variables, true)
+	value, err := cmd.variablesAsJSON("variables", variables, true)
 	if err != nil {
 		return nil, err
 	}
@@ -131,6 +139,14 @@ func (cmd *CreateInstanceCommand) StartBeforeElement(elementID string) CreateIns
 	return cmd
 }
 
+// StartInstructions appends the start instructions to the ones of the command
+func (cmd *CreateInstanceCommand) StartInstructions(instructions ...StartInstruction) CreateInstanceCommandStep3 {
+	for _, instruction := range instructions {
+		cmd.StartBeforeElement(instruction.ElementID)
+	}
+	return cmd
+}
+
 func (cmd *CreateInstanceCommand) Version(version int32) CreateInstanceCommandStep3 {
 	cmd.request.Version = version
 	return cmd
@@ -158,9 +174,12 @@ func (cmd *CreateInstanceCommand) WithResult() CreateInstanceWithResultCommandSt
 			Request: &cmd.request,
 		},
 		Command: Command{
-			mixin:       cmd.mixin,
-			gateway:     cmd.gateway,
-			shouldRetry: cmd.shouldRetry,
+			mixin:            cmd.mixin,
+			claimCheck:       cmd.claimCheck,
+			maxVariablesSize: cmd.maxVariablesSize,
+			offloaded:        cmd.offloaded,
+			gateway:          cmd.gateway,
+			shouldRetry:      cmd.shouldRetry,
 		},
 	}
 }
@@ -171,15 +190,23 @@ func (cmd *CreateInstanceWithResultCommand) FetchVariables(variableNames ...stri
 }
 
 func (cmd *CreateInstanceCommand) Send(ctx context.Context) (*pb.CreateProcessInstanceResponse, error) {
+	if err := cmd.offloadVariables(ctx, "variables", &cmd.request.Variables); err != nil {
+		return nil, err
+	}
+
 	response, err := cmd.gateway.CreateProcessInstance(ctx, &cmd.request)
 	if cmd.shouldRetry(ctx, err) {
 		return cmd.Send(ctx)
 	}
 
-	return response, err
+	return response, zberrors.Wrap(err)
 }
 
 func (cmd *CreateInstanceWithResultCommand) Send(ctx context.Context) (*pb.CreateProcessInstanceWithResultResponse, error) {
+	if err := cmd.offloadVariables(ctx, "variables", &cmd.request.Request.Variables); err != nil {
+		return nil, err
+	}
+
 	cmd.request.RequestTimeout = getLongPollingMillis(ctx)
 
 	response, err := cmd.gateway.CreateProcessInstanceWithResult(ctx, &cmd.request)
@@ -187,15 +214,11 @@ func (cmd *CreateInstanceWithResultCommand) Send(ctx context.Context) (*pb.Creat
 		return cmd.Send(ctx)
 	}
 
-	return response, err
+	return response, zberrors.Wrap(err)
 }
 
-func NewCreateInstanceCommand(gateway pb.GatewayClient, pred retryPredicate) CreateInstanceCommandStep1 {
+func NewCreateInstanceCommand(gateway pb.GatewayClient, pred retryPredicate, opts ...CommandOption) CreateInstanceCommandStep1 {
 	return &CreateInstanceCommand{
-		Command: Command{
-			mixin:       utils.NewJSONStringSerializer(),
-			gateway:     gateway,
-			shouldRetry: pred,
-		},
+		Command: newCommand(gateway, pred, opts),
 	}
 }
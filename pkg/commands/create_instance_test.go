@@ -899,3 +899,43 @@ func TestCreateProcessInstanceWithResultAndFetchEmptyVariablesListCommand(t *tes
 		t.Errorf("Failed to receive response")
 	}
 }
+
+func TestCreateProcessInstanceWithStartInstructions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock_pb.NewMockGatewayClient(ctrl)
+
+	request := &pb.CreateProcessInstanceRequest{
+		BpmnProcessId: "foo",
+		Version:       LatestVersion,
+		StartInstructions: []*pb.ProcessInstanceCreationStartInstruction{
+			{
+				ElementId: "my-start-element",
+			},
+			{
+				ElementId: "my-other-start-element",
+			},
+		},
+	}
+	stub := &pb.CreateProcessInstanceResponse{
+		ProcessInstanceKey: 5632,
+	}
+
+	client.EXPECT().CreateProcessInstance(gomock.Any(), &utils.RPCTestMsg{Msg: request}).Return(stub, nil)
+
+	command := NewCreateInstanceCommand(client, func(context.Context, error) bool { return false })
+
+	response, err := command.BPMNProcessId("foo").LatestVersion().StartInstructions(
+		StartInstruction{ElementID: "my-start-element"},
+		StartInstruction{ElementID: "my-other-start-element"},
+	).Send(context.Background())
+
+	if err != nil {
+		t.Errorf("Failed to send request")
+	}
+
+	if response != stub {
+		t.Errorf("Failed to receive response")
+	}
+}
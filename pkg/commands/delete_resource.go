@@ -17,6 +17,7 @@ package commands
 import (
 	"context"
 
+	zberrors "github.com/camunda-community-hub/zeebe-client-go/v8/pkg/errors"
 	"github.com/camunda-community-hub/zeebe-client-go/v8/pkg/pb"
 )
 
@@ -39,7 +40,7 @@ func (cmd *DeleteResourceCommand) Send(ctx context.Context) (*pb.DeleteResourceR
 		return cmd.Send(ctx)
 	}
 
-	return response, err
+	return response, zberrors.Wrap(err)
 }
 
 func (cmd *DeleteResourceCommand) ResourceKey(key int64) DispatchDeleteResourceCommand {
@@ -16,21 +16,28 @@ package commands
 
 import (
 	"context"
-	"log"
 	"os"
 
+	zberrors "github.com/camunda-community-hub/zeebe-client-go/v8/pkg/errors"
 	"github.com/camunda-community-hub/zeebe-client-go/v8/pkg/pb"
 )
 
 type DeployCommand struct {
 	Command
 	request pb.DeployProcessRequest //nolint
+	// err is the first error which occurred while adding resources, returned by Send
+	err error
 }
 
+// AddResourceFile adds the file at the given path as a resource named after the path. If the file cannot be read, the
+// error is returned by Send.
 func (cmd *DeployCommand) AddResourceFile(path string) *DeployCommand {
 	b, err := os.ReadFile(path)
 	if err != nil {
-		log.Fatal(err)
+		if cmd.err == nil {
+			cmd.err = err
+		}
+		return cmd
 	}
 	return cmd.AddResource(b, path)
 }
@@ -42,12 +49,16 @@ func (cmd *DeployCommand) AddResource(definition []byte, name string) *DeployCom
 }
 
 func (cmd *DeployCommand) Send(ctx context.Context) (*pb.DeployProcessResponse, error) { //nolint
+	if cmd.err != nil {
+		return nil, cmd.err
+	}
+
 	response, err := cmd.gateway.DeployProcess(ctx, &cmd.request) //nolint
 	if cmd.shouldRetry(ctx, err) {
 		return cmd.Send(ctx)
 	}
 
-	return response, err
+	return response, zberrors.Wrap(err)
 }
 
 // Deprecated: Use NewDeployResourceCommand instead. To be removed in 8.1.0.
@@ -16,37 +16,209 @@ package commands
 
 import (
 	"context"
-	"log"
+	"errors"
+	"fmt"
+	"io/fs"
 	"os"
+	"path"
+	"path/filepath"
+	"strings"
 
+	"github.com/camunda-community-hub/zeebe-client-go/v8/pkg/entities"
+	zberrors "github.com/camunda-community-hub/zeebe-client-go/v8/pkg/errors"
+	"github.com/camunda-community-hub/zeebe-client-go/v8/pkg/model"
 	"github.com/camunda-community-hub/zeebe-client-go/v8/pkg/pb"
 )
 
+// deployableResourceExtensions are the file extensions picked up by AddResourcesFromFS and AddResourceDir
+var deployableResourceExtensions = []string{".bpmn", ".dmn", ".form"}
+
 type DeployResourceCommand struct {
 	Command
 	request pb.DeployResourceRequest
+	// err is the first error which occurred while adding resources, returned by Send
+	err error
 }
 
+// AddResourceFile adds the file at the given path as a resource named after the path. If the file cannot be read, the
+// error is returned by Send.
 func (cmd *DeployResourceCommand) AddResourceFile(path string) *DeployResourceCommand {
 	b, err := os.ReadFile(path)
 	if err != nil {
-		log.Fatal(err)
+		if cmd.err == nil {
+			cmd.err = err
+		}
+		return cmd
 	}
 	return cmd.AddResource(b, path)
 }
 
+// AddResourcesFromFS adds the .bpmn, .dmn and .form files of the file system matching any of the patterns, as
+// understood by fs.Glob, or all of them if no pattern is given. Resources are named after their path in the file
+// system. This allows deploying resources embedded with go:embed:
+//
+//	//go:embed processes
+//	var processes embed.FS
+//
+//	command, err := client.NewDeployResourceCommand().AddResourcesFromFS(processes, "processes/*.bpmn")
+func (cmd *DeployResourceCommand) AddResourcesFromFS(fsys fs.FS, patterns ...string) (*DeployResourceCommand, error) {
+	var names []string
+	if len(patterns) == 0 {
+		err := fs.WalkDir(fsys, ".", func(name string, entry fs.DirEntry, err error) error {
+			if err == nil && !entry.IsDir() && isDeployableResource(name) {
+				names = append(names, name)
+			}
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if len(names) == 0 {
+			return nil, fmt.Errorf("no resources to deploy found in the file system")
+		}
+	}
+
+	added := make(map[string]bool)
+	for _, pattern := range patterns {
+		matches, err := fs.Glob(fsys, pattern)
+		if err != nil {
+			return nil, err
+		}
+
+		found := false
+		for _, name := range matches {
+			info, err := fs.Stat(fsys, name)
+			if err != nil {
+				return nil, err
+			}
+
+			if !info.IsDir() && isDeployableResource(name) {
+				found = true
+				if !added[name] {
+					added[name] = true
+					names = append(names, name)
+				}
+			}
+		}
+
+		if !found {
+			return nil, fmt.Errorf("no resources to deploy match the pattern '%s'", pattern)
+		}
+	}
+
+	return cmd.addResourcesFromFS(fsys, names, func(name string) string { return name })
+}
+
+// AddResourceDir adds the .bpmn, .dmn and .form files in the given directory, and in its subdirectories if recursive
+// is true. Like with AddResourceFile, resources are named after their path.
+func (cmd *DeployResourceCommand) AddResourceDir(dir string, recursive bool) (*DeployResourceCommand, error) {
+	fsys := os.DirFS(dir)
+
+	var names []string
+	err := fs.WalkDir(fsys, ".", func(name string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if entry.IsDir() {
+			if !recursive && name != "." {
+				return fs.SkipDir
+			}
+		} else if isDeployableResource(name) {
+			names = append(names, name)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(names) == 0 {
+		return nil, fmt.Errorf("no resources to deploy found in directory '%s'", dir)
+	}
+
+	return cmd.addResourcesFromFS(fsys, names, func(name string) string { return filepath.Join(dir, filepath.FromSlash(name)) })
+}
+
+func (cmd *DeployResourceCommand) addResourcesFromFS(fsys fs.FS, names []string, resourceName func(string) string) (*DeployResourceCommand, error) {
+	resources := make([]*pb.Resource, 0, len(names))
+	for _, name := range names {
+		b, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, &pb.Resource{Content: b, Name: resourceName(name)})
+	}
+
+	cmd.request.Resources = append(cmd.request.Resources, resources...)
+	return cmd, nil
+}
+
+func isDeployableResource(name string) bool {
+	extension := strings.ToLower(path.Ext(name))
+	for _, deployable := range deployableResourceExtensions {
+		if extension == deployable {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (cmd *DeployResourceCommand) AddResource(definition []byte, name string) *DeployResourceCommand {
 	cmd.request.Resources = append(cmd.request.Resources, &pb.Resource{Content: definition, Name: name})
 	return cmd
 }
 
 func (cmd *DeployResourceCommand) Send(ctx context.Context) (*pb.DeployResourceResponse, error) {
+	if cmd.err != nil {
+		return nil, cmd.err
+	}
+
 	response, err := cmd.gateway.DeployResource(ctx, &cmd.request)
 	if cmd.shouldRetry(ctx, err) {
 		return cmd.Send(ctx)
 	}
 
-	return response, err
+	return response, zberrors.Wrap(err)
+}
+
+// Validate checks the structure of the BPMN, DMN and form resources locally, without contacting the gateway. If
+// problems are found, a *model.ValidationError listing the problems of all resources is returned. Passing validation
+// does not guarantee that the gateway accepts the resources.
+func (cmd *DeployResourceCommand) Validate() error {
+	if cmd.err != nil {
+		return cmd.err
+	}
+
+	var problems []model.Problem
+	for _, resource := range cmd.request.Resources {
+		var validationErr *model.ValidationError
+		if err := model.Validate(resource.Name, resource.Content); errors.As(err, &validationErr) {
+			problems = append(problems, validationErr.Problems...)
+		} else if err != nil {
+			return err
+		}
+	}
+
+	if len(problems) > 0 {
+		return &model.ValidationError{Problems: problems}
+	}
+
+	return nil
+}
+
+// SendTyped deploys the resources like Send, but returns the deployed processes, decisions, decision requirements
+// graphs and forms grouped by type.
+func (cmd *DeployResourceCommand) SendTyped(ctx context.Context) (*entities.DeploymentResult, error) {
+	response, err := cmd.Send(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entities.DeploymentResult{DeployResourceResponse: response}, nil
 }
 
 //nolint:revive
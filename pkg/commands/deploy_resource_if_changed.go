@@ -0,0 +1,180 @@
+// Copyright © 2018 Camunda Services GmbH (info@camunda.com)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/camunda-community-hub/zeebe-client-go/v8/pkg/pb"
+)
+
+// DeploymentReport describes the outcome of DeployResourceCommand.SendIfChanged
+type DeploymentReport struct {
+	// Deployed is true if the resources were sent to the gateway, i.e. if any of them changed
+	Deployed bool
+	// Response is the response of the gateway, or nil if nothing was deployed
+	Response *pb.DeployResourceResponse
+	// Resources contains one entry per resource of the command, in the order they were added
+	Resources []ResourceReport
+}
+
+// ResourceReport describes the outcome of deploying a single resource
+type ResourceReport struct {
+	Name     string
+	Checksum string
+	// Changed is true if the content differs from the last known deployment, or if there is none
+	Changed bool
+	// NewVersion is true if the deployment produced a version of any definition in the resource which differs from the
+	// last known deployment, or if there is none
+	NewVersion bool
+	// Definitions are the definitions deployed from the resource, or the last known ones if the resource was unchanged
+	Definitions []DeployedDefinition
+}
+
+// NewVersions returns the names of the resources which produced new versions
+func (report *DeploymentReport) NewVersions() []string {
+	var names []string
+	for _, resource := range report.Resources {
+		if resource.NewVersion {
+			names = append(names, resource.Name)
+		}
+	}
+
+	return names
+}
+
+// SendIfChanged deploys the resources only if the content of any of them differs from the last known deployment in
+// the store, which makes it safe to call on every start of an application. If anything changed, all resources are
+// deployed together, and the store is updated with the deployed definitions.
+func (cmd *DeployResourceCommand) SendIfChanged(ctx context.Context, store DeploymentStore) (*DeploymentReport, error) {
+	if cmd.err != nil {
+		return nil, cmd.err
+	}
+
+	tenantID := cmd.request.TenantId
+	report := &DeploymentReport{Resources: make([]ResourceReport, len(cmd.request.Resources))}
+	known := make([]*DeployedResource, len(cmd.request.Resources))
+	changed := false
+
+	for i, resource := range cmd.request.Resources {
+		checksum := sha256.Sum256(resource.Content)
+		report.Resources[i] = ResourceReport{Name: resource.Name, Checksum: hex.EncodeToString(checksum[:])}
+
+		deployed, err := store.Get(tenantID, resource.Name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read last deployment of '%s': %w", resource.Name, err)
+		}
+
+		known[i] = deployed
+		if deployed == nil || deployed.Checksum != report.Resources[i].Checksum {
+			report.Resources[i].Changed = true
+			changed = true
+		} else {
+			report.Resources[i].Definitions = deployed.Definitions
+		}
+	}
+
+	if !changed {
+		return report, nil
+	}
+
+	response, err := cmd.Send(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	report.Deployed = true
+	report.Response = response
+
+	definitions := deployedDefinitionsByResource(response)
+	deployedResources := make([]*DeployedResource, len(report.Resources))
+	for i := range report.Resources {
+		resource := &report.Resources[i]
+		resource.Definitions = definitions[resource.Name]
+		resource.NewVersion = known[i] == nil || !sameDefinitionVersions(known[i].Definitions, resource.Definitions)
+
+		deployedResources[i] = &DeployedResource{
+			Name:        resource.Name,
+			TenantID:    tenantID,
+			Checksum:    resource.Checksum,
+			Definitions: resource.Definitions,
+		}
+	}
+
+	if err = store.Put(deployedResources...); err != nil {
+		return report, fmt.Errorf("deployed resources, but failed to record the deployment: %w", err)
+	}
+
+	return report, nil
+}
+
+// groups the deployed definitions by the name of the resource they were parsed from; decisions are grouped with the
+// decision requirements graph they are part of, as they carry no resource name themselves
+func deployedDefinitionsByResource(response *pb.DeployResourceResponse) map[string][]DeployedDefinition {
+	definitions := make(map[string][]DeployedDefinition)
+	drgResources := make(map[int64]string)
+
+	for _, deployment := range response.GetDeployments() {
+		if drg := deployment.GetDecisionRequirements(); drg != nil {
+			drgResources[drg.DecisionRequirementsKey] = drg.ResourceName
+		}
+	}
+
+	for _, deployment := range response.GetDeployments() {
+		switch metadata := deployment.Metadata.(type) {
+		case *pb.Deployment_Process:
+			process := metadata.Process
+			definitions[process.ResourceName] = append(definitions[process.ResourceName],
+				DeployedDefinition{ID: process.BpmnProcessId, Key: process.ProcessDefinitionKey, Version: process.Version})
+		case *pb.Deployment_DecisionRequirements:
+			drg := metadata.DecisionRequirements
+			definitions[drg.ResourceName] = append(definitions[drg.ResourceName],
+				DeployedDefinition{ID: drg.DmnDecisionRequirementsId, Key: drg.DecisionRequirementsKey, Version: drg.Version})
+		case *pb.Deployment_Decision:
+			decision := metadata.Decision
+			resourceName := drgResources[decision.DecisionRequirementsKey]
+			definitions[resourceName] = append(definitions[resourceName],
+				DeployedDefinition{ID: decision.DmnDecisionId, Key: decision.DecisionKey, Version: decision.Version})
+		case *pb.Deployment_Form:
+			form := metadata.Form
+			definitions[form.ResourceName] = append(definitions[form.ResourceName],
+				DeployedDefinition{ID: form.FormId, Key: form.FormKey, Version: form.Version})
+		}
+	}
+
+	return definitions
+}
+
+func sameDefinitionVersions(known, deployed []DeployedDefinition) bool {
+	if len(known) != len(deployed) {
+		return false
+	}
+
+	versions := make(map[string]int32, len(known))
+	for _, definition := range known {
+		versions[definition.ID] = definition.Version
+	}
+
+	for _, definition := range deployed {
+		if version, ok := versions[definition.ID]; !ok || version != definition.Version {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,153 @@
+// Copyright © 2018 Camunda Services GmbH (info@camunda.com)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/camunda-community-hub/zeebe-client-go/v8/internal/mock_pb"
+	"github.com/camunda-community-hub/zeebe-client-go/v8/internal/utils"
+	"github.com/camunda-community-hub/zeebe-client-go/v8/pkg/pb"
+	"github.com/golang/mock/gomock"
+)
+
+func TestDeployResourceCommand_SendIfChanged(t *testing.T) {
+	for name, store := range map[string]DeploymentStore{
+		"in memory": NewInMemoryDeploymentStore(),
+		"file":      NewFileDeploymentStore(filepath.Join(t.TempDir(), "deployments", "store.json")),
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			client := mock_pb.NewMockGatewayClient(ctrl)
+			newCommand := func(order string) *DeployResourceCommand {
+				return NewDeployResourceCommand(client, func(context.Context, error) bool { return false }).
+					AddResource([]byte(order), "order.bpmn").
+					AddResource([]byte("discount"), "discount.dmn")
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), utils.DefaultTestTimeout)
+			defer cancel()
+
+			// first deployment
+			client.EXPECT().DeployResource(gomock.Any(), gomock.Any()).Return(deployResponse(1, 1), nil)
+
+			report, err := newCommand("order-v1").SendIfChanged(ctx, store)
+			if err != nil {
+				t.Fatal("Failed to deploy: ", err)
+			}
+			if !report.Deployed || report.Response == nil {
+				t.Error("Expected resources to be deployed")
+			}
+			if !reflect.DeepEqual([]string{"order.bpmn", "discount.dmn"}, report.NewVersions()) {
+				t.Errorf("Expected all resources to have new versions, but got %v", report.NewVersions())
+			}
+
+			// unchanged resources are not deployed again
+			report, err = newCommand("order-v1").SendIfChanged(ctx, store)
+			if err != nil {
+				t.Fatal("Failed to deploy: ", err)
+			}
+			if report.Deployed || report.Response != nil || len(report.NewVersions()) > 0 {
+				t.Errorf("Expected nothing to be deployed, but got %+v", report)
+			}
+			expectedDefinitions := []DeployedDefinition{{ID: "order", Key: 11, Version: 1}}
+			if !reflect.DeepEqual(expectedDefinitions, report.Resources[0].Definitions) {
+				t.Errorf("Expected last known definitions %v, but got %v", expectedDefinitions, report.Resources[0].Definitions)
+			}
+
+			// a changed resource is deployed together with the unchanged ones
+			request := &pb.DeployResourceRequest{
+				Resources: []*pb.Resource{
+					{Name: "order.bpmn", Content: []byte("order-v2")},
+					{Name: "discount.dmn", Content: []byte("discount")},
+				},
+			}
+			client.EXPECT().DeployResource(gomock.Any(), &utils.RPCTestMsg{Msg: request}).Return(deployResponse(2, 1), nil)
+
+			report, err = newCommand("order-v2").SendIfChanged(ctx, store)
+			if err != nil {
+				t.Fatal("Failed to deploy: ", err)
+			}
+			if !report.Deployed {
+				t.Error("Expected resources to be deployed")
+			}
+			if !report.Resources[0].Changed || report.Resources[1].Changed {
+				t.Errorf("Expected only order.bpmn to be changed, but got %+v", report.Resources)
+			}
+			if !reflect.DeepEqual([]string{"order.bpmn"}, report.NewVersions()) {
+				t.Errorf("Expected only order.bpmn to have a new version, but got %v", report.NewVersions())
+			}
+
+			deployed, err := store.Get("", "order.bpmn")
+			if err != nil || deployed == nil || deployed.Definitions[0].Version != 2 {
+				t.Errorf("Expected store to contain the new version, but got %+v (%v)", deployed, err)
+			}
+		})
+	}
+}
+
+func deployResponse(processVersion, decisionVersion int32) *pb.DeployResourceResponse {
+	return &pb.DeployResourceResponse{
+		Key: 1,
+		Deployments: []*pb.Deployment{
+			{Metadata: &pb.Deployment_Process{Process: &pb.ProcessMetadata{
+				BpmnProcessId: "order", Version: processVersion, ProcessDefinitionKey: int64(10 + processVersion), ResourceName: "order.bpmn",
+			}}},
+			{Metadata: &pb.Deployment_Decision{Decision: &pb.DecisionMetadata{
+				DmnDecisionId: "discount", Version: decisionVersion, DecisionKey: 20, DecisionRequirementsKey: 30,
+			}}},
+			{Metadata: &pb.Deployment_DecisionRequirements{DecisionRequirements: &pb.DecisionRequirementsMetadata{
+				DmnDecisionRequirementsId: "discounts", Version: decisionVersion, DecisionRequirementsKey: 30, ResourceName: "discount.dmn",
+			}}},
+		},
+	}
+}
+
+func TestFileDeploymentStore_ConcurrentWriters(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "store.json")
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for i := 0; i < cap(errs); i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			// separate stores, like separate processes, do not share a lock
+			errs <- NewFileDeploymentStore(path).Put(&DeployedResource{Name: fmt.Sprintf("process-%d.bpmn", i)})
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Error("Failed to write the store: ", err)
+		}
+	}
+
+	if leftovers, _ := filepath.Glob(path + ".*.tmp"); len(leftovers) > 0 {
+		t.Errorf("Expected no temporary files to be left, but got %v", leftovers)
+	}
+	if _, err := NewFileDeploymentStore(path).Get("", "process-0.bpmn"); err != nil {
+		t.Error("Failed to read the store: ", err)
+	}
+}
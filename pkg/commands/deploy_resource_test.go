@@ -16,12 +16,18 @@ package commands
 
 import (
 	"context"
+	"errors"
+	"os"
+	"path/filepath"
 	"testing"
+	"testing/fstest"
 
 	"github.com/camunda-community-hub/zeebe-client-go/v8/internal/mock_pb"
 	"github.com/camunda-community-hub/zeebe-client-go/v8/internal/utils"
+	"github.com/camunda-community-hub/zeebe-client-go/v8/pkg/model"
 	"github.com/camunda-community-hub/zeebe-client-go/v8/pkg/pb"
 	"github.com/golang/mock/gomock"
+	"google.golang.org/protobuf/proto"
 )
 
 func TestDeployResourceCommand_AddResourceFile(t *testing.T) {
@@ -153,3 +159,190 @@ func TestDeployResourceCommand_TenantId(t *testing.T) {
 		t.Errorf("Failed to receive response")
 	}
 }
+
+func TestDeployResourceCommand_AddResourcesFromFS(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock_pb.NewMockGatewayClient(ctrl)
+
+	fsys := fstest.MapFS{
+		"processes/order.bpmn":         {Data: []byte("order")},
+		"processes/nested/refund.bpmn": {Data: []byte("refund")},
+		"processes/README.md":          {Data: []byte("readme")},
+		"decisions/discount.dmn":       {Data: []byte("discount")},
+		"forms/address.form":           {Data: []byte("address")},
+	}
+
+	request := &pb.DeployResourceRequest{
+		Resources: []*pb.Resource{
+			{Name: "processes/order.bpmn", Content: []byte("order")},
+			{Name: "decisions/discount.dmn", Content: []byte("discount")},
+		},
+	}
+	stub := &pb.DeployResourceResponse{}
+
+	client.EXPECT().DeployResource(gomock.Any(), &utils.RPCTestMsg{Msg: request}).Return(stub, nil)
+
+	command, err := NewDeployResourceCommand(client, func(context.Context, error) bool { return false }).
+		AddResourcesFromFS(fsys, "processes/*", "decisions/*.dmn", "processes/order.bpmn")
+	if err != nil {
+		t.Fatal("Failed to add resources: ", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), utils.DefaultTestTimeout)
+	defer cancel()
+
+	response, err := command.Send(ctx)
+	if err != nil {
+		t.Errorf("Failed to send request")
+	}
+
+	if response != stub {
+		t.Errorf("Failed to receive response")
+	}
+}
+
+func TestDeployResourceCommand_AddResourcesFromFSWithoutPatterns(t *testing.T) {
+	fsys := fstest.MapFS{
+		"processes/order.bpmn":         {Data: []byte("order")},
+		"processes/nested/refund.bpmn": {Data: []byte("refund")},
+		"processes/README.md":          {Data: []byte("readme")},
+		"forms/address.form":           {Data: []byte("address")},
+	}
+
+	command, err := NewDeployResourceCommand(nil, nil).AddResourcesFromFS(fsys)
+	if err != nil {
+		t.Fatal("Failed to add resources: ", err)
+	}
+
+	expected := []*pb.Resource{
+		{Name: "forms/address.form", Content: []byte("address")},
+		{Name: "processes/nested/refund.bpmn", Content: []byte("refund")},
+		{Name: "processes/order.bpmn", Content: []byte("order")},
+	}
+	if !proto.Equal(&pb.DeployResourceRequest{Resources: expected}, &command.request) {
+		t.Errorf("Expected resources %v, but got %v", expected, command.request.Resources)
+	}
+}
+
+func TestDeployResourceCommand_AddResourcesFromFSWithoutMatch(t *testing.T) {
+	fsys := fstest.MapFS{"processes/README.md": {Data: []byte("readme")}}
+
+	if _, err := NewDeployResourceCommand(nil, nil).AddResourcesFromFS(fsys, "processes/*"); err == nil {
+		t.Error("Expected an error if no resources match a pattern")
+	}
+}
+
+func TestDeployResourceCommand_AddResourcesFromFSWithoutResources(t *testing.T) {
+	fsys := fstest.MapFS{"processes/README.md": {Data: []byte("readme")}}
+
+	if _, err := NewDeployResourceCommand(nil, nil).AddResourcesFromFS(fsys); err == nil {
+		t.Error("Expected an error if the file system contains no resources")
+	}
+}
+
+func TestDeployResourceCommand_AddResourceDir(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "order.bpmn"), "order")
+	writeFile(t, filepath.Join(dir, "notes.txt"), "notes")
+	writeFile(t, filepath.Join(dir, "nested", "discount.dmn"), "discount")
+
+	for _, recursive := range []bool{false, true} {
+		command, err := NewDeployResourceCommand(nil, nil).AddResourceDir(dir, recursive)
+		if err != nil {
+			t.Fatal("Failed to add resources: ", err)
+		}
+
+		expected := []*pb.Resource{{Name: filepath.Join(dir, "order.bpmn"), Content: []byte("order")}}
+		if recursive {
+			expected = []*pb.Resource{
+				{Name: filepath.Join(dir, "nested", "discount.dmn"), Content: []byte("discount")},
+				{Name: filepath.Join(dir, "order.bpmn"), Content: []byte("order")},
+			}
+		}
+
+		if !proto.Equal(&pb.DeployResourceRequest{Resources: expected}, &command.request) {
+			t.Errorf("Expected resources %v with recursive=%v, but got %v", expected, recursive, command.request.Resources)
+		}
+	}
+
+	if _, err := NewDeployResourceCommand(nil, nil).AddResourceDir(filepath.Join(dir, "missing"), true); err == nil {
+		t.Error("Expected an error for a missing directory")
+	}
+}
+
+func TestDeployResourceCommand_AddResourceFileReturnsErrorOnSend(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// the gateway must not be called
+	client := mock_pb.NewMockGatewayClient(ctrl)
+
+	_, err := NewDeployResourceCommand(client, func(context.Context, error) bool { return false }).
+		AddResourceFile("../../cmd/zbctl/testdata/missing.bpmn").
+		AddResourceFile("../../cmd/zbctl/testdata/demo-process.bpmn").
+		Send(context.Background())
+
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Expected error for missing file, but got %v", err)
+	}
+}
+
+func writeFile(t *testing.T, name, content string) {
+	if err := os.MkdirAll(filepath.Dir(name), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(name, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDeployResourceCommand_SendTyped(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock_pb.NewMockGatewayClient(ctrl)
+
+	process := &pb.ProcessMetadata{BpmnProcessId: "process", Version: 1, ResourceName: "model.bpmn"}
+	stub := &pb.DeployResourceResponse{
+		Key:         1,
+		Deployments: []*pb.Deployment{{Metadata: &pb.Deployment_Process{Process: process}}},
+	}
+
+	client.EXPECT().DeployResource(gomock.Any(), gomock.Any()).Return(stub, nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), utils.DefaultTestTimeout)
+	defer cancel()
+
+	result, err := NewDeployResourceCommand(client, func(context.Context, error) bool { return false }).
+		AddResource([]byte("process"), "model.bpmn").
+		SendTyped(ctx)
+
+	if err != nil {
+		t.Errorf("Failed to send request")
+	}
+
+	if result.DeployResourceResponse != stub || result.ProcessByID("process") != process {
+		t.Errorf("Failed to receive typed response")
+	}
+}
+
+func TestDeployResourceCommand_Validate(t *testing.T) {
+	command := NewDeployResourceCommand(nil, nil).
+		AddResource([]byte(`<bpmn:definitions xmlns:bpmn="http://www.omg.org/spec/BPMN/20100524/MODEL" />`), "invalid.bpmn").
+		AddResource([]byte(`{"components": []}`), "invalid.form").
+		AddResource([]byte("anything"), "other.txt")
+
+	err := command.Validate()
+
+	var validationErr *model.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("Expected validation error, got %v", err)
+	}
+
+	if len(validationErr.Problems) != 2 ||
+		validationErr.Problems[0].Resource != "invalid.bpmn" || validationErr.Problems[1].Resource != "invalid.form" {
+		t.Errorf("Unexpected validation problems: %v", validationErr.Problems)
+	}
+}
@@ -0,0 +1,169 @@
+// Copyright © 2018 Camunda Services GmbH (info@camunda.com)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/camunda-community-hub/zeebe-client-go/v8/internal/utils"
+)
+
+// DeployedResource is the last known deployment of a resource, as kept by a DeploymentStore
+type DeployedResource struct {
+	Name     string `json:"name"`
+	TenantID string `json:"tenantId,omitempty"`
+	// Checksum is the hex encoded SHA-256 hash of the resource content
+	Checksum string `json:"checksum"`
+	// Definitions are the processes, decisions, decision requirements graphs or forms deployed from the resource
+	Definitions []DeployedDefinition `json:"definitions"`
+}
+
+// DeployedDefinition is a process, decision, decision requirements graph or form deployed from a resource
+type DeployedDefinition struct {
+	// ID is the BPMN process ID, DMN decision ID, DMN decision requirements ID or form ID
+	ID      string `json:"id"`
+	Key     int64  `json:"key"`
+	Version int32  `json:"version"`
+}
+
+// DeploymentStore keeps the last known deployment of each resource for DeployResourceCommand.SendIfChanged. It can be
+// shared between replicas, e.g. backed by a database, so that replicas skip resources another one already deployed.
+// It does not coordinate replicas though: those starting at the same time may all deploy a changed resource, which
+// Zeebe tolerates, as deploying unchanged content again does not create a new version.
+type DeploymentStore interface {
+	// Get returns the last known deployment of the resource with the given name for the tenant, or nil if there is none
+	Get(tenantID, name string) (*DeployedResource, error)
+	// Put records the given deployments, replacing any previous ones of the same resources
+	Put(resources ...*DeployedResource) error
+}
+
+type deploymentStoreKey struct {
+	tenantID string
+	name     string
+}
+
+type inMemoryDeploymentStore struct {
+	lock      sync.RWMutex
+	resources map[deploymentStoreKey]*DeployedResource
+}
+
+// NewInMemoryDeploymentStore returns a DeploymentStore which keeps deployments in memory only
+func NewInMemoryDeploymentStore() DeploymentStore {
+	return &inMemoryDeploymentStore{resources: make(map[deploymentStoreKey]*DeployedResource)}
+}
+
+func (store *inMemoryDeploymentStore) Get(tenantID, name string) (*DeployedResource, error) {
+	store.lock.RLock()
+	defer store.lock.RUnlock()
+
+	return store.resources[deploymentStoreKey{tenantID: tenantID, name: name}], nil
+}
+
+func (store *inMemoryDeploymentStore) Put(resources ...*DeployedResource) error {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+
+	for _, resource := range resources {
+		store.resources[deploymentStoreKey{tenantID: resource.TenantID, name: resource.Name}] = resource
+	}
+
+	return nil
+}
+
+type fileDeploymentStore struct {
+	path string
+	lock sync.Mutex
+}
+
+// NewFileDeploymentStore returns a DeploymentStore which keeps deployments as JSON in the file at the given path. The
+// file and its parent directories are created on the first Put.
+func NewFileDeploymentStore(path string) DeploymentStore {
+	return &fileDeploymentStore{path: path}
+}
+
+func (store *fileDeploymentStore) Get(tenantID, name string) (*DeployedResource, error) {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+
+	resources, err := store.read()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, resource := range resources {
+		if resource.TenantID == tenantID && resource.Name == name {
+			return resource, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (store *fileDeploymentStore) Put(resources ...*DeployedResource) error {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+
+	stored, err := store.read()
+	if err != nil {
+		return err
+	}
+
+	for _, resource := range resources {
+		replaced := false
+		for i, existing := range stored {
+			if existing.TenantID == resource.TenantID && existing.Name == resource.Name {
+				stored[i] = resource
+				replaced = true
+				break
+			}
+		}
+
+		if !replaced {
+			stored = append(stored, resource)
+		}
+	}
+
+	contents, err := json.MarshalIndent(stored, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(store.path), 0700); err != nil {
+		return err
+	}
+
+	return utils.WriteFileAtomically(store.path, contents, 0600)
+}
+
+func (store *fileDeploymentStore) read() ([]*DeployedResource, error) {
+	contents, err := os.ReadFile(store.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var resources []*DeployedResource
+	if err = json.Unmarshal(contents, &resources); err != nil {
+		return nil, err
+	}
+
+	return resources, nil
+}
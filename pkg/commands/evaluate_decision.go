@@ -18,7 +18,7 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/camunda-community-hub/zeebe-client-go/v8/internal/utils"
+	zberrors "github.com/camunda-community-hub/zeebe-client-go/v8/pkg/errors"
 	"github.com/camunda-community-hub/zeebe-client-go/v8/pkg/pb"
 )
 
@@ -59,7 +59,7 @@ func (cmd *EvaluateDecisionCommand) TenantId(tenantId string) EvaluateDecisionCo
 }
 
 func (cmd *EvaluateDecisionCommand) VariablesFromString(variables string) (EvaluateDecisionCommandStep2, error) {
-	err := cmd.mixin.Validate("variables", variables)
+	variables, err := cmd.validateVariables("variables", variables)
 	if err != nil {
 		return nil, err
 	}
@@ -73,7 +73,7 @@ func (cmd *EvaluateDecisionCommand) VariablesFromStringer(variables fmt.Stringer
 }
 
 func (cmd *EvaluateDecisionCommand) VariablesFromObject(variables interface{}) (EvaluateDecisionCommandStep2, error) {
-	value, err := cmd.mixin.AsJSON("variables", variables, false)
+	value, err := cmd.variablesAsJSON("variables", variables, false)
 	if err != nil {
 		return nil, err
 	}
@@ -83,7 +83,7 @@ func (cmd *EvaluateDecisionCommand) VariablesFromObject(variables interface{}) (
 }
 
 func (cmd *EvaluateDecisionCommand) VariablesFromObjectIgnoreOmitempty(variables interface{}) (EvaluateDecisionCommandStep2, error) {
-	value, err := cmd.mixin.AsJSON("variables", variables, true)
+	value, err := cmd.variablesAsJSON("variables", variables, true)
 	if err != nil {
 		return nil, err
 	}
@@ -108,20 +108,20 @@ func (cmd *EvaluateDecisionCommand) DecisionId(id string) EvaluateDecisionComman
 }
 
 func (cmd *EvaluateDecisionCommand) Send(ctx context.Context) (*pb.EvaluateDecisionResponse, error) {
+	if err := cmd.offloadVariables(ctx, "variables", &cmd.request.Variables); err != nil {
+		return nil, err
+	}
+
 	response, err := cmd.gateway.EvaluateDecision(ctx, &cmd.request)
 	if cmd.shouldRetry(ctx, err) {
 		return cmd.Send(ctx)
 	}
 
-	return response, err
+	return response, zberrors.Wrap(err)
 }
 
-func NewEvaluateDecisionCommand(gateway pb.GatewayClient, pred retryPredicate) EvaluateDecisionCommandStep1 {
+func NewEvaluateDecisionCommand(gateway pb.GatewayClient, pred retryPredicate, opts ...CommandOption) EvaluateDecisionCommandStep1 {
 	return &EvaluateDecisionCommand{
-		Command: Command{
-			mixin:       utils.NewJSONStringSerializer(),
-			gateway:     gateway,
-			shouldRetry: pred,
-		},
+		Command: newCommand(gateway, pred, opts),
 	}
 }
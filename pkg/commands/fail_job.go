@@ -20,7 +20,7 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/camunda-community-hub/zeebe-client-go/v8/internal/utils"
+	zberrors "github.com/camunda-community-hub/zeebe-client-go/v8/pkg/errors"
 	"github.com/camunda-community-hub/zeebe-client-go/v8/pkg/pb"
 )
 
@@ -74,7 +74,7 @@ func (cmd *FailJobCommand) ErrorMessage(errorMessage string) FailJobCommandStep3
 }
 
 func (cmd *FailJobCommand) VariablesFromString(variables string) (DispatchFailJobCommand, error) {
-	err := cmd.mixin.Validate("variables", variables)
+	variables, err := cmd.validateVariables("variables", variables)
 	if err != nil {
 		return nil, err
 	}
@@ -88,7 +88,7 @@ func (cmd *FailJobCommand) VariablesFromStringer(variables fmt.Stringer) (Dispat
 }
 
 func (cmd *FailJobCommand) VariablesFromObject(variables interface{}) (DispatchFailJobCommand, error) {
-	value, err := cmd.mixin.AsJSON("variables", variables, false)
+	value, err := cmd.variablesAsJSON("variables", variables, false)
 	if err != nil {
 		return nil, err
 	}
@@ -98,7 +98,7 @@ func (cmd *FailJobCommand) VariablesFromObject(variables interface{}) (DispatchF
 }
 
 func (cmd *FailJobCommand) VariablesFromObjectIgnoreOmitempty(variables interface{}) (DispatchFailJobCommand, error) {
-	value, err := cmd.mixin.AsJSON("variables", variables, true)
+	value, err := cmd.variablesAsJSON("variables", variables, true)
 	if err != nil {
 		return nil, err
 	}
@@ -112,20 +112,20 @@ func (cmd *FailJobCommand) VariablesFromMap(variables map[string]interface{}) (D
 }
 
 func (cmd *FailJobCommand) Send(ctx context.Context) (*pb.FailJobResponse, error) {
+	if err := cmd.offloadVariables(ctx, "variables", &cmd.request.Variables); err != nil {
+		return nil, err
+	}
+
 	response, err := cmd.gateway.FailJob(ctx, &cmd.request)
 	if cmd.shouldRetry(ctx, err) {
 		return cmd.Send(ctx)
 	}
 
-	return response, err
+	return response, zberrors.Wrap(err)
 }
 
-func NewFailJobCommand(gateway pb.GatewayClient, pred retryPredicate) FailJobCommandStep1 {
+func NewFailJobCommand(gateway pb.GatewayClient, pred retryPredicate, opts ...CommandOption) FailJobCommandStep1 {
 	return &FailJobCommand{
-		Command: Command{
-			mixin:       utils.NewJSONStringSerializer(),
-			gateway:     gateway,
-			shouldRetry: pred,
-		},
+		Command: newCommand(gateway, pred, opts),
 	}
 }
@@ -0,0 +1,170 @@
+// Copyright © 2018 Camunda Services GmbH (info@camunda.com)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"errors"
+)
+
+// Future is the result of a command sent asynchronously with SendAsync
+type Future[T any] struct {
+	done     chan struct{}
+	response T
+	err      error
+}
+
+func newFuture[T any]() *Future[T] {
+	return &Future[T]{done: make(chan struct{})}
+}
+
+func (future *Future[T]) resolve(response T, err error) {
+	future.response = response
+	future.err = err
+	close(future.done)
+}
+
+// Done returns a channel which is closed once the command was sent
+func (future *Future[T]) Done() <-chan struct{} {
+	return future.done
+}
+
+// Get blocks until the command was sent and returns its response, or the error of ctx if it is done first
+func (future *Future[T]) Get(ctx context.Context) (T, error) {
+	select {
+	case <-future.done:
+		return future.response, future.err
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
+
+// Err returns the error of the command, or nil if it succeeded or was not sent yet
+func (future *Future[T]) Err() error {
+	select {
+	case <-future.done:
+		return future.err
+	default:
+		return nil
+	}
+}
+
+// Executor bounds the number of commands sent asynchronously at the same time
+type Executor struct {
+	inFlight chan struct{}
+}
+
+// NewExecutor returns an executor sending at most maxInFlight commands at the same time. Zero or less means no limit.
+func NewExecutor(maxInFlight int) *Executor {
+	if maxInFlight <= 0 {
+		return &Executor{}
+	}
+	return &Executor{inFlight: make(chan struct{}, maxInFlight)}
+}
+
+func (executor *Executor) acquire(ctx context.Context) error {
+	if executor == nil || executor.inFlight == nil {
+		return nil
+	}
+
+	select {
+	case executor.inFlight <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (executor *Executor) release() {
+	if executor != nil && executor.inFlight != nil {
+		<-executor.inFlight
+	}
+}
+
+// SendAsync sends a command on another goroutine, usually given as the Send method of the command, and returns the
+// future of its response:
+//
+//	future := commands.SendAsync(ctx, executor, command.Send)
+//
+// While the executor has its maximum number of commands in flight, SendAsync blocks until one of them is done or ctx
+// is done, in which case the future fails with the error of ctx. A nil executor does not bound the commands in flight.
+func SendAsync[T any](ctx context.Context, executor *Executor, send func(context.Context) (T, error)) *Future[T] {
+	future := newFuture[T]()
+
+	if err := executor.acquire(ctx); err != nil {
+		var zero T
+		future.resolve(zero, err)
+		return future
+	}
+
+	go func() {
+		defer executor.release()
+		future.resolve(send(ctx))
+	}()
+	return future
+}
+
+// WaitAll waits until all futures are done and returns their responses, in the order of the futures, and the errors
+// of the failed futures joined together. It returns early with the error of ctx if it is done first.
+func WaitAll[T any](ctx context.Context, futures ...*Future[T]) ([]T, error) {
+	responses := make([]T, len(futures))
+	var errs []error
+	for i, future := range futures {
+		select {
+		case <-future.done:
+		case <-ctx.Done():
+			return responses, ctx.Err()
+		}
+
+		if future.err != nil {
+			errs = append(errs, future.err)
+		}
+		responses[i] = future.response
+	}
+	return responses, errors.Join(errs...)
+}
+
+// FirstError waits until one of the futures fails and returns its error, or returns nil once all futures succeeded.
+// It returns early with the error of ctx if it is done first.
+func FirstError[T any](ctx context.Context, futures ...*Future[T]) error {
+	failed := make(chan error, len(futures))
+	succeeded := make(chan struct{}, len(futures))
+	for _, future := range futures {
+		go func(future *Future[T]) {
+			select {
+			case <-future.Done():
+				if future.err != nil {
+					failed <- future.err
+				} else {
+					succeeded <- struct{}{}
+				}
+			case <-ctx.Done():
+			}
+		}(future)
+	}
+
+	for done := 0; done < len(futures); {
+		select {
+		case err := <-failed:
+			return err
+		case <-succeeded:
+			done++
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+// Copyright © 2018 Camunda Services GmbH (info@camunda.com)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/camunda-community-hub/zeebe-client-go/v8/internal/mock_pb"
+	"github.com/camunda-community-hub/zeebe-client-go/v8/internal/utils"
+	"github.com/camunda-community-hub/zeebe-client-go/v8/pkg/pb"
+	"github.com/golang/mock/gomock"
+)
+
+func TestSendAsync(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock_pb.NewMockGatewayClient(ctrl)
+
+	request := &pb.PublishMessageRequest{
+		Name:           "foo",
+		CorrelationKey: "bar",
+	}
+	stub := &pb.PublishMessageResponse{
+		Key: 1,
+	}
+
+	client.EXPECT().PublishMessage(gomock.Any(), &utils.RPCTestMsg{Msg: request}).Return(stub, nil)
+
+	command := NewPublishMessageCommand(client, func(context.Context, error) bool { return false })
+
+	ctx, cancel := context.WithTimeout(context.Background(), utils.DefaultTestTimeout)
+	defer cancel()
+
+	future := SendAsync(ctx, nil, command.MessageName("foo").CorrelationKey("bar").Send)
+	response, err := future.Get(ctx)
+
+	if err != nil {
+		t.Errorf("Failed to send request")
+	}
+
+	if response != stub {
+		t.Errorf("Failed to receive response")
+	}
+}
+
+func TestSendAsyncBoundsCommandsInFlight(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), utils.DefaultTestTimeout)
+	defer cancel()
+
+	var inFlight, maxInFlight int32
+	send := func(context.Context) (int, error) {
+		current := atomic.AddInt32(&inFlight, 1)
+		for {
+			previous := atomic.LoadInt32(&maxInFlight)
+			if current <= previous || atomic.CompareAndSwapInt32(&maxInFlight, previous, current) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		atomic.AddInt32(&inFlight, -1)
+		return 1, nil
+	}
+
+	executor := NewExecutor(2)
+	var futures []*Future[int]
+	for i := 0; i < 10; i++ {
+		futures = append(futures, SendAsync(ctx, executor, send))
+	}
+
+	responses, err := WaitAll(ctx, futures...)
+	if err != nil {
+		t.Errorf("Failed to send commands: %v", err)
+	}
+	if len(responses) != 10 {
+		t.Errorf("Expected 10 responses, got %d", len(responses))
+	}
+	if maxInFlight > 2 {
+		t.Errorf("Expected at most 2 commands in flight, got %d", maxInFlight)
+	}
+}
+
+func TestWaitAllJoinsErrors(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), utils.DefaultTestTimeout)
+	defer cancel()
+
+	first, second := errors.New("first"), errors.New("second")
+	futures := []*Future[int]{
+		SendAsync(ctx, nil, func(context.Context) (int, error) { return 0, first }),
+		SendAsync(ctx, nil, func(context.Context) (int, error) { return 2, nil }),
+		SendAsync(ctx, nil, func(context.Context) (int, error) { return 0, second }),
+	}
+
+	responses, err := WaitAll(ctx, futures...)
+
+	if !errors.Is(err, first) || !errors.Is(err, second) {
+		t.Errorf("Expected both errors to be joined, got %v", err)
+	}
+	if responses[1] != 2 {
+		t.Errorf("Expected response of succeeded command, got %d", responses[1])
+	}
+}
+
+func TestFirstError(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), utils.DefaultTestTimeout)
+	defer cancel()
+
+	failure := errors.New("failure")
+	blocked := make(chan struct{})
+	defer close(blocked)
+
+	futures := []*Future[int]{
+		SendAsync(ctx, nil, func(context.Context) (int, error) { <-blocked; return 1, nil }),
+		SendAsync(ctx, nil, func(context.Context) (int, error) { return 0, failure }),
+	}
+
+	if err := FirstError(ctx, futures...); !errors.Is(err, failure) {
+		t.Errorf("Expected first error, got %v", err)
+	}
+
+	succeeded := SendAsync(ctx, nil, func(context.Context) (int, error) { return 1, nil })
+	if err := FirstError(ctx, succeeded); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+}
+
+func TestSendAsyncFailsWhenContextIsDoneWhileWaitingForExecutor(t *testing.T) {
+	blocked := make(chan struct{})
+	defer close(blocked)
+
+	executor := NewExecutor(1)
+	SendAsync(context.Background(), executor, func(context.Context) (int, error) { <-blocked; return 1, nil })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	future := SendAsync(ctx, executor, func(context.Context) (int, error) { return 1, nil })
+	if _, err := future.Get(context.Background()); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context error, got %v", err)
+	}
+}
@@ -20,7 +20,7 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/camunda-community-hub/zeebe-client-go/v8/internal/utils"
+	zberrors "github.com/camunda-community-hub/zeebe-client-go/v8/pkg/errors"
 	"github.com/camunda-community-hub/zeebe-client-go/v8/pkg/pb"
 )
 
@@ -73,7 +73,7 @@ func (cmd *PublishMessageCommand) TenantId(tenantId string) PublishMessageComman
 }
 
 func (cmd *PublishMessageCommand) VariablesFromObject(variables interface{}) (PublishMessageCommandStep3, error) {
-	value, err := cmd.mixin.AsJSON("variables", variables, false)
+	value, err := cmd.variablesAsJSON("variables", variables, false)
 	if err != nil {
 		return nil, err
 	}
@@ -83,7 +83,7 @@ func (cmd *PublishMessageCommand) VariablesFromObject(variables interface{}) (Pu
 }
 
 func (cmd *PublishMessageCommand) VariablesFromObjectIgnoreOmitempty(variables interface{}) (PublishMessageCommandStep3, error) {
-	value, err := cmd.mixin.AsJSON("variables", variables, true)
+	value, err := cmd.variablesAsJSON("variables", variables, true)
 	if err != nil {
 		return nil, err
 	}
@@ -97,7 +97,7 @@ func (cmd *PublishMessageCommand) VariablesFromMap(variables map[string]interfac
 }
 
 func (cmd *PublishMessageCommand) VariablesFromString(variables string) (PublishMessageCommandStep3, error) {
-	err := cmd.mixin.Validate("variables", variables)
+	variables, err := cmd.validateVariables("variables", variables)
 	if err != nil {
 		return nil, err
 	}
@@ -126,19 +126,19 @@ func (cmd *PublishMessageCommand) MessageName(name string) PublishMessageCommand
 }
 
 func (cmd *PublishMessageCommand) Send(ctx context.Context) (*pb.PublishMessageResponse, error) {
+	if err := cmd.offloadVariables(ctx, "variables", &cmd.request.Variables); err != nil {
+		return nil, err
+	}
+
 	response, err := cmd.gateway.PublishMessage(ctx, &cmd.request)
 	if cmd.shouldRetry(ctx, err) {
 		return cmd.Send(ctx)
 	}
-	return response, err
+	return response, zberrors.Wrap(err)
 }
 
-func NewPublishMessageCommand(gateway pb.GatewayClient, pred retryPredicate) PublishMessageCommandStep1 {
+func NewPublishMessageCommand(gateway pb.GatewayClient, pred retryPredicate, opts ...CommandOption) PublishMessageCommandStep1 {
 	return &PublishMessageCommand{
-		Command: Command{
-			mixin:       utils.NewJSONStringSerializer(),
-			gateway:     gateway,
-			shouldRetry: pred,
-		},
+		Command: newCommand(gateway, pred, opts),
 	}
 }
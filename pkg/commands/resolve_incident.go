@@ -18,6 +18,7 @@ package commands
 import (
 	"context"
 
+	zberrors "github.com/camunda-community-hub/zeebe-client-go/v8/pkg/errors"
 	"github.com/camunda-community-hub/zeebe-client-go/v8/pkg/pb"
 )
 
@@ -49,7 +50,7 @@ func (cmd *ResolveIncidentCommand) Send(ctx context.Context) (*pb.ResolveInciden
 		return cmd.Send(ctx)
 	}
 
-	return response, err
+	return response, zberrors.Wrap(err)
 }
 
 func NewResolveIncidentCommand(gateway pb.GatewayClient, pred retryPredicate) ResolveIncidentCommandStep1 {
@@ -21,6 +21,7 @@ import (
 	"time"
 
 	"github.com/camunda-community-hub/zeebe-client-go/v8/pkg/entities"
+	zberrors "github.com/camunda-community-hub/zeebe-client-go/v8/pkg/errors"
 	"github.com/camunda-community-hub/zeebe-client-go/v8/pkg/pb"
 )
 
@@ -99,7 +100,7 @@ func (cmd *StreamJobsCommand) Send(ctx context.Context) error {
 
 	stream, err := cmd.openStream(ctx)
 	if err != nil {
-		return err
+		return zberrors.Wrap(err)
 	}
 
 	for {
@@ -110,13 +111,13 @@ func (cmd *StreamJobsCommand) Send(ctx context.Context) error {
 			}
 
 			if !cmd.shouldRetry(ctx, err) {
-				return err
+				return zberrors.Wrap(err)
 			}
 
 			stream, err = cmd.openStream(ctx)
 			if err != nil {
 				log.Printf("Failed to reopen job stream: %v\n", err)
-				return err
+				return zberrors.Wrap(err)
 			}
 		}
 
@@ -140,15 +141,12 @@ func (cmd *StreamJobsCommand) openStream(ctx context.Context) (pb.Gateway_Stream
 	return stream, nil
 }
 
-func NewStreamJobsCommand(gateway pb.GatewayClient, pred retryPredicate) StreamJobsCommandStep1 {
+func NewStreamJobsCommand(gateway pb.GatewayClient, pred retryPredicate, opts ...CommandOption) StreamJobsCommandStep1 {
 	return &StreamJobsCommand{
 		request: pb.StreamActivatedJobsRequest{
 			Timeout: DefaultJobTimeoutInMs,
 			Worker:  DefaultJobWorkerName,
 		},
-		Command: Command{
-			gateway:     gateway,
-			shouldRetry: pred,
-		},
+		Command: newCommand(gateway, pred, opts),
 	}
 }
@@ -19,7 +19,7 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/camunda-community-hub/zeebe-client-go/v8/internal/utils"
+	zberrors "github.com/camunda-community-hub/zeebe-client-go/v8/pkg/errors"
 	"github.com/camunda-community-hub/zeebe-client-go/v8/pkg/pb"
 )
 
@@ -64,7 +64,7 @@ func (c *ThrowErrorCommand) ErrorMessage(errorMsg string) DispatchThrowErrorComm
 }
 
 func (c *ThrowErrorCommand) VariablesFromString(variables string) (DispatchThrowErrorCommand, error) {
-	err := c.mixin.Validate("variables", variables)
+	variables, err := c.validateVariables("variables", variables)
 	if err != nil {
 		return nil, err
 	}
@@ -78,7 +78,7 @@ func (c *ThrowErrorCommand) VariablesFromStringer(variables fmt.Stringer) (Dispa
 }
 
 func (c *ThrowErrorCommand) VariablesFromObject(variables interface{}) (DispatchThrowErrorCommand, error) {
-	value, err := c.mixin.AsJSON("variables", variables, false)
+	value, err := c.variablesAsJSON("variables", variables, false)
 	if err != nil {
 		return nil, err
 	}
@@ -88,7 +88,7 @@ func (c *ThrowErrorCommand) VariablesFromObject(variables interface{}) (Dispatch
 }
 
 func (c *ThrowErrorCommand) VariablesFromObjectIgnoreOmitempty(variables interface{}) (DispatchThrowErrorCommand, error) {
-	value, err := c.mixin.AsJSON("variables", variables, true)
+	value, err := c.variablesAsJSON("variables", variables, true)
 	if err != nil {
 		return nil, err
 	}
@@ -102,21 +102,21 @@ func (c *ThrowErrorCommand) VariablesFromMap(variables map[string]interface{}) (
 }
 
 func (c *ThrowErrorCommand) Send(ctx context.Context) (*pb.ThrowErrorResponse, error) {
+	if err := c.offloadVariables(ctx, "variables", &c.request.Variables); err != nil {
+		return nil, err
+	}
+
 	response, err := c.gateway.ThrowError(ctx, &c.request)
 	if c.shouldRetry(ctx, err) {
 		return c.Send(ctx)
 	}
 
-	return response, err
+	return response, zberrors.Wrap(err)
 }
 
-func NewThrowErrorCommand(gateway pb.GatewayClient, pred retryPredicate) ThrowErrorCommandStep1 {
+func NewThrowErrorCommand(gateway pb.GatewayClient, pred retryPredicate, opts ...CommandOption) ThrowErrorCommandStep1 {
 	return &ThrowErrorCommand{
-		Command: Command{
-			mixin:       utils.NewJSONStringSerializer(),
-			gateway:     gateway,
-			shouldRetry: pred,
-		},
+		Command: newCommand(gateway, pred, opts),
 	}
 
 }
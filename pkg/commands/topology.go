@@ -18,6 +18,7 @@ package commands
 import (
 	"context"
 
+	zberrors "github.com/camunda-community-hub/zeebe-client-go/v8/pkg/errors"
 	"github.com/camunda-community-hub/zeebe-client-go/v8/pkg/pb"
 )
 
@@ -31,7 +32,7 @@ func (cmd *TopologyCommand) Send(ctx context.Context) (*pb.TopologyResponse, err
 		return cmd.Send(ctx)
 	}
 
-	return response, err
+	return response, zberrors.Wrap(err)
 }
 
 func NewTopologyCommand(gateway pb.GatewayClient, pred retryPredicate) *TopologyCommand {
@@ -18,6 +18,7 @@ package commands
 import (
 	"context"
 
+	zberrors "github.com/camunda-community-hub/zeebe-client-go/v8/pkg/errors"
 	"github.com/camunda-community-hub/zeebe-client-go/v8/pkg/pb"
 )
 
@@ -60,7 +61,7 @@ func (cmd *UpdateJobRetriesCommand) Send(ctx context.Context) (*pb.UpdateJobRetr
 		return cmd.Send(ctx)
 	}
 
-	return response, err
+	return response, zberrors.Wrap(err)
 }
 
 func NewUpdateJobRetriesCommand(gateway pb.GatewayClient, pred retryPredicate) UpdateJobRetriesCommandStep1 {
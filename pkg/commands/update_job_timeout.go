@@ -17,6 +17,7 @@ package commands
 import (
 	"context"
 
+	zberrors "github.com/camunda-community-hub/zeebe-client-go/v8/pkg/errors"
 	"github.com/camunda-community-hub/zeebe-client-go/v8/pkg/pb"
 )
 
@@ -55,7 +56,7 @@ func (cmd *UpdateJobTimeoutCommand) Send(ctx context.Context) (*pb.UpdateJobTime
 		return cmd.Send(ctx)
 	}
 
-	return response, err
+	return response, zberrors.Wrap(err)
 }
 
 func NewUpdateJobTimeoutCommand(gateway pb.GatewayClient, pred retryPredicate) UpdateJobTimeoutCommandStep1 {
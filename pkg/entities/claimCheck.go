@@ -0,0 +1,184 @@
+// Copyright © 2018 Camunda Services GmbH (info@camunda.com)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package entities
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ClaimCheckReferenceKey is the key of the JSON object replacing an offloaded variable, whose value is the reference
+// returned by the BlobStore, e.g. {"$claimCheck": "bucket/4f2a"}
+const ClaimCheckReferenceKey = "$claimCheck"
+
+// BlobStore keeps variable values too large to be sent through the gateway, e.g. in an object storage
+type BlobStore interface {
+	// Put stores the value and returns a reference to retrieve it
+	Put(ctx context.Context, value []byte) (string, error)
+	// Get returns the value stored under the reference
+	Get(ctx context.Context, reference string) ([]byte, error)
+}
+
+// ClaimCheck offloads large variables to a BlobStore, following the claim check pattern: variables sent by commands
+// which are larger than Threshold are stored in the BlobStore and replaced by a reference, and the references found in
+// the variables of activated jobs are resolved by job workers, or when the variables are read through JobVariables.
+// Only top level variables are offloaded.
+//
+// Nothing is offloaded unless both Store and a positive Threshold are set.
+type ClaimCheck struct {
+	Store BlobStore
+	// Threshold is the size in bytes of the JSON value of a variable above which the variable is offloaded
+	Threshold int
+}
+
+// ErrUnresolvedClaimCheck is wrapped by the VariableError returned when the variables of a job are read with
+// Job.GetVariablesAs or Job.GetVariablesAsMap while some are still offloaded to a claim check store
+var ErrUnresolvedClaimCheck = errors.New("offloaded to a claim check store and not resolved, read it with JobVariables")
+
+type claimCheckReference struct {
+	Reference string `json:"$claimCheck"`
+}
+
+// Offload replaces the top level variables of the JSON object which are larger than the threshold by references to
+// the values stored in the BlobStore. The variables are returned unchanged if none is offloaded.
+func (c *ClaimCheck) Offload(ctx context.Context, variables string) (string, error) {
+	if c.Store == nil || c.Threshold <= 0 {
+		return variables, nil
+	}
+
+	var values map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(variables), &values); err != nil {
+		// not a JSON object, which the gateway rejects anyway
+		return variables, nil
+	}
+
+	offloaded := false
+	for name, value := range values {
+		if len(value) <= c.Threshold {
+			continue
+		}
+
+		reference, err := c.Store.Put(ctx, value)
+		if err != nil {
+			return "", fmt.Errorf("failed to offload variable '%s': %w", name, err)
+		}
+
+		values[name], err = json.Marshal(claimCheckReference{Reference: reference})
+		if err != nil {
+			return "", err
+		}
+		offloaded = true
+	}
+
+	if !offloaded {
+		return variables, nil
+	}
+
+	b, err := json.Marshal(values)
+	return string(b), err
+}
+
+// Resolve returns the value stored in the BlobStore if the JSON value is a reference, or the value itself otherwise
+func (c *ClaimCheck) Resolve(ctx context.Context, value json.RawMessage) (json.RawMessage, error) {
+	reference, ok := claimCheckReferenceOf(value)
+	if !ok || c.Store == nil {
+		return value, nil
+	}
+
+	resolved, err := c.Store.Get(ctx, reference)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve claim check '%s': %w", reference, err)
+	}
+
+	return resolved, nil
+}
+
+// ResolveVariables replaces the references among the top level variables of the JSON object by the values stored in
+// the BlobStore. The variables are returned unchanged if none is a reference.
+func (c *ClaimCheck) ResolveVariables(ctx context.Context, variables string) (string, error) {
+	if c.Store == nil || !strings.Contains(variables, ClaimCheckReferenceKey) {
+		return variables, nil
+	}
+
+	var values map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(variables), &values); err != nil {
+		return variables, nil
+	}
+
+	resolved := false
+	for name, value := range values {
+		if _, ok := claimCheckReferenceOf(value); !ok {
+			continue
+		}
+
+		value, err := c.Resolve(ctx, value)
+		if err != nil {
+			return "", &VariableError{Name: name, Err: err}
+		}
+
+		values[name] = value
+		resolved = true
+	}
+
+	if !resolved {
+		return variables, nil
+	}
+
+	b, err := json.Marshal(values)
+	return string(b), err
+}
+
+// unresolvedClaimCheck returns the name of a top level variable which is a claim check reference, if any
+func unresolvedClaimCheck(variables string) (string, bool) {
+	if !strings.Contains(variables, ClaimCheckReferenceKey) {
+		return "", false
+	}
+
+	var values map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(variables), &values); err != nil {
+		return "", false
+	}
+
+	for name, value := range values {
+		if _, ok := claimCheckReferenceOf(value); ok {
+			return name, true
+		}
+	}
+
+	return "", false
+}
+
+func claimCheckReferenceOf(value json.RawMessage) (string, bool) {
+	// cheap test first, as most values are no references
+	if !bytes.Contains(value, []byte(ClaimCheckReferenceKey)) {
+		return "", false
+	}
+
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(value, &object); err != nil || len(object) != 1 {
+		return "", false
+	}
+
+	var reference string
+	if err := json.Unmarshal(object[ClaimCheckReferenceKey], &reference); err != nil {
+		return "", false
+	}
+
+	return reference, true
+}
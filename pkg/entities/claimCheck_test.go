@@ -0,0 +1,192 @@
+// Copyright © 2018 Camunda Services GmbH (info@camunda.com)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package entities
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/camunda-community-hub/zeebe-client-go/v8/pkg/pb"
+	"github.com/google/go-cmp/cmp"
+)
+
+type memoryBlobStore struct {
+	blobs map[string][]byte
+	gets  int
+}
+
+func (s *memoryBlobStore) Put(_ context.Context, value []byte) (string, error) {
+	reference := fmt.Sprintf("blob-%d", len(s.blobs))
+	s.blobs[reference] = value
+	return reference, nil
+}
+
+func (s *memoryBlobStore) Get(_ context.Context, reference string) ([]byte, error) {
+	s.gets++
+	value, ok := s.blobs[reference]
+	if !ok {
+		return nil, errors.New("no such blob")
+	}
+	return value, nil
+}
+
+func TestClaimCheck_Offload(t *testing.T) {
+	store := &memoryBlobStore{blobs: make(map[string][]byte)}
+	claimCheck := &ClaimCheck{Store: store, Threshold: 10}
+
+	got, err := claimCheck.Offload(context.Background(), `{"small": 1, "large": "0123456789abcdef"}`)
+	if err != nil {
+		t.Fatalf("claimCheck.Offload() = %v", err)
+	}
+
+	if want := `{"large":{"$claimCheck":"blob-0"},"small":1}`; got != want {
+		t.Errorf("claimCheck.Offload() = %s, want %s", got, want)
+	}
+
+	if want := `"0123456789abcdef"`; string(store.blobs["blob-0"]) != want {
+		t.Errorf("stored %s, want %s", store.blobs["blob-0"], want)
+	}
+}
+
+func TestClaimCheck_OffloadKeepsSmallVariables(t *testing.T) {
+	claimCheck := &ClaimCheck{Store: &memoryBlobStore{blobs: make(map[string][]byte)}, Threshold: 10}
+
+	variables := `{ "small": 1 }`
+	if got, err := claimCheck.Offload(context.Background(), variables); err != nil || got != variables {
+		t.Errorf("claimCheck.Offload() = %s, %v, want unchanged variables", got, err)
+	}
+}
+
+func TestJobVariablesResolveClaimChecks(t *testing.T) {
+	store := &memoryBlobStore{blobs: map[string][]byte{"blob-0": []byte(`{"sku": "a"}`)}}
+	job := Job{&pb.ActivatedJob{Variables: `{"order": {"$claimCheck": "blob-0"}, "small": 1}`}}
+	variables := NewJobVariables(job, nil, &ClaimCheck{Store: store, Threshold: 10})
+
+	sku, err := GetVariableAt[string](variables, "/order/sku")
+	if err != nil || sku != "a" {
+		t.Errorf("GetVariableAt(variables, \"/order/sku\") = %q, %v", sku, err)
+	}
+
+	if _, err := GetVariable[map[string]string](variables, "order"); err != nil || store.gets != 1 {
+		t.Errorf("expected resolved variable to be kept, got %d reads and %v", store.gets, err)
+	}
+
+	var all map[string]interface{}
+	err = variables.As(&all)
+	want := map[string]interface{}{"order": map[string]interface{}{"sku": "a"}, "small": float64(1)}
+	if diff := cmp.Diff(want, all); diff != "" || err != nil {
+		t.Errorf("variables.As() differs (-want +got):\n%s, %v", diff, err)
+	}
+
+	if job.Variables != `{"order": {"$claimCheck": "blob-0"}, "small": 1}` {
+		t.Errorf("expected the job to be unchanged, got %s", job.Variables)
+	}
+}
+
+func TestJobVariablesClaimCheckErrorNamesVariable(t *testing.T) {
+	job := Job{&pb.ActivatedJob{Variables: `{"order": {"$claimCheck": "missing"}}`}}
+	variables := NewJobVariables(job, nil, &ClaimCheck{Store: &memoryBlobStore{blobs: make(map[string][]byte)}})
+
+	_, err := GetVariable[map[string]string](variables, "order")
+	var variableErr *VariableError
+	if !errors.As(err, &variableErr) || variableErr.Name != "order" {
+		t.Errorf("GetVariable(variables, \"order\") = %v, want error naming the variable", err)
+	}
+}
+
+func TestClaimCheck_OffloadRequiresStoreAndThreshold(t *testing.T) {
+	variables := `{"large": "0123456789abcdef"}`
+	for _, claimCheck := range []*ClaimCheck{
+		{Threshold: 10},
+		{Store: &memoryBlobStore{blobs: make(map[string][]byte)}},
+		{Store: &memoryBlobStore{blobs: make(map[string][]byte)}, Threshold: -1},
+	} {
+		if got, err := claimCheck.Offload(context.Background(), variables); err != nil || got != variables {
+			t.Errorf("claimCheck.Offload() = %s, %v, want unchanged variables", got, err)
+		}
+	}
+}
+
+func TestJobVariables_Resolve(t *testing.T) {
+	store := &memoryBlobStore{blobs: map[string][]byte{"blob-0": []byte(`"a"`), "blob-1": []byte(`"b"`)}}
+	job := Job{&pb.ActivatedJob{Variables: `{"first": {"$claimCheck": "blob-0"}, "second": {"$claimCheck": "blob-1"}}`}}
+	variables := NewJobVariables(job, nil, &ClaimCheck{Store: store, Threshold: 10})
+
+	if err := variables.Resolve(context.Background(), "first", "missing"); err != nil || store.gets != 1 {
+		t.Errorf("variables.Resolve(ctx, \"first\") = %v, with %d reads", err, store.gets)
+	}
+
+	if err := variables.Resolve(context.Background()); err != nil || store.gets != 2 {
+		t.Errorf("variables.Resolve(ctx) = %v, with %d reads", err, store.gets)
+	}
+
+	if second, err := GetVariable[string](variables, "second"); err != nil || second != "b" || store.gets != 2 {
+		t.Errorf("GetVariable(variables, \"second\") = %q, %v, with %d reads", second, err, store.gets)
+	}
+}
+
+func TestJobVariables_ResolveIsCancelledWithContext(t *testing.T) {
+	job := Job{&pb.ActivatedJob{Variables: `{"order": {"$claimCheck": "blob-0"}}`}}
+	variables := NewJobVariables(job, nil, &ClaimCheck{Store: contextBlobStore{}, Threshold: 10})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := variables.Resolve(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("variables.Resolve() = %v, want context.Canceled", err)
+	}
+}
+
+// contextBlobStore blocks until the context is done
+type contextBlobStore struct{}
+
+func (contextBlobStore) Put(ctx context.Context, _ []byte) (string, error) {
+	<-ctx.Done()
+	return "", ctx.Err()
+}
+
+func (contextBlobStore) Get(ctx context.Context, _ string) ([]byte, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestClaimCheck_ResolveVariables(t *testing.T) {
+	store := &memoryBlobStore{blobs: map[string][]byte{"blob-0": []byte(`{"sku":"a"}`)}}
+	claimCheck := &ClaimCheck{Store: store}
+
+	got, err := claimCheck.ResolveVariables(context.Background(), `{"order": {"$claimCheck": "blob-0"}, "small": 1}`)
+	if want := `{"order":{"sku":"a"},"small":1}`; err != nil || got != want {
+		t.Errorf("claimCheck.ResolveVariables() = %s, %v, want %s", got, err, want)
+	}
+
+	variables := `{ "small": 1 }`
+	if got, err := claimCheck.ResolveVariables(context.Background(), variables); err != nil || got != variables {
+		t.Errorf("claimCheck.ResolveVariables() = %s, %v, want unchanged variables", got, err)
+	}
+}
+
+func TestJobGetVariablesAsRejectsUnresolvedClaimChecks(t *testing.T) {
+	job := Job{&pb.ActivatedJob{Variables: `{"order": {"$claimCheck": "blob-0"}, "small": 1}`}}
+
+	var variables map[string]interface{}
+	err := job.GetVariablesAs(&variables)
+
+	var variableErr *VariableError
+	if !errors.Is(err, ErrUnresolvedClaimCheck) || !errors.As(err, &variableErr) || variableErr.Name != "order" {
+		t.Errorf("job.GetVariablesAs() = %v, want error naming the unresolved variable", err)
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright © 2018 Camunda Services GmbH (info@camunda.com)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package entities
+
+import (
+	"github.com/camunda-community-hub/zeebe-client-go/v8/pkg/pb"
+)
+
+// DeploymentResult represents the outcome of deploying resources, grouping the deployed processes, decisions,
+// decision requirements graphs (DRGs) and forms by type.
+//
+// See https://docs.camunda.io/docs/components/concepts/resource-deployments/ for details on deployments.
+type DeploymentResult struct {
+	*pb.DeployResourceResponse
+}
+
+// Processes returns the metadata of the deployed processes, in the order returned by the gateway.
+func (r *DeploymentResult) Processes() []*pb.ProcessMetadata {
+	var processes []*pb.ProcessMetadata
+	for _, deployment := range r.GetDeployments() {
+		if process := deployment.GetProcess(); process != nil {
+			processes = append(processes, process)
+		}
+	}
+	return processes
+}
+
+// Decisions returns the metadata of the deployed decisions, in the order returned by the gateway.
+func (r *DeploymentResult) Decisions() []*pb.DecisionMetadata {
+	var decisions []*pb.DecisionMetadata
+	for _, deployment := range r.GetDeployments() {
+		if decision := deployment.GetDecision(); decision != nil {
+			decisions = append(decisions, decision)
+		}
+	}
+	return decisions
+}
+
+// DRGs returns the metadata of the deployed decision requirements graphs, in the order returned by the gateway.
+func (r *DeploymentResult) DRGs() []*pb.DecisionRequirementsMetadata {
+	var drgs []*pb.DecisionRequirementsMetadata
+	for _, deployment := range r.GetDeployments() {
+		if drg := deployment.GetDecisionRequirements(); drg != nil {
+			drgs = append(drgs, drg)
+		}
+	}
+	return drgs
+}
+
+// Forms returns the metadata of the deployed forms, in the order returned by the gateway.
+func (r *DeploymentResult) Forms() []*pb.FormMetadata {
+	var forms []*pb.FormMetadata
+	for _, deployment := range r.GetDeployments() {
+		if form := deployment.GetForm(); form != nil {
+			forms = append(forms, form)
+		}
+	}
+	return forms
+}
+
+// ProcessByID returns the metadata of the deployed process with the given BPMN process ID, or nil if it was not part of
+// the deployment.
+func (r *DeploymentResult) ProcessByID(bpmnProcessID string) *pb.ProcessMetadata {
+	for _, process := range r.Processes() {
+		if process.BpmnProcessId == bpmnProcessID {
+			return process
+		}
+	}
+	return nil
+}
+
+// DecisionByID returns the metadata of the deployed decision with the given DMN decision ID, or nil if it was not part
+// of the deployment.
+func (r *DeploymentResult) DecisionByID(dmnDecisionID string) *pb.DecisionMetadata {
+	for _, decision := range r.Decisions() {
+		if decision.DmnDecisionId == dmnDecisionID {
+			return decision
+		}
+	}
+	return nil
+}
+
+// DRGByID returns the metadata of the deployed decision requirements graph with the given DMN decision requirements
+// ID, or nil if it was not part of the deployment.
+func (r *DeploymentResult) DRGByID(dmnDecisionRequirementsID string) *pb.DecisionRequirementsMetadata {
+	for _, drg := range r.DRGs() {
+		if drg.DmnDecisionRequirementsId == dmnDecisionRequirementsID {
+			return drg
+		}
+	}
+	return nil
+}
+
+// FormByID returns the metadata of the deployed form with the given form ID, or nil if it was not part of the
+// deployment.
+func (r *DeploymentResult) FormByID(formID string) *pb.FormMetadata {
+	for _, form := range r.Forms() {
+		if form.FormId == formID {
+			return form
+		}
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+// Copyright © 2018 Camunda Services GmbH (info@camunda.com)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package entities
+
+import (
+	"testing"
+
+	"github.com/camunda-community-hub/zeebe-client-go/v8/pkg/pb"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+var (
+	process = &pb.ProcessMetadata{BpmnProcessId: "process", Version: 1, ProcessDefinitionKey: 1, ResourceName: "model.bpmn"}
+	other   = &pb.ProcessMetadata{BpmnProcessId: "other", Version: 2, ProcessDefinitionKey: 2, ResourceName: "model.bpmn"}
+	drg     = &pb.DecisionRequirementsMetadata{DmnDecisionRequirementsId: "force_users", Version: 1, DecisionRequirementsKey: 3, ResourceName: "drg.dmn"}
+	jedi    = &pb.DecisionMetadata{DmnDecisionId: "jedi_or_sith", Version: 1, DecisionKey: 4, DmnDecisionRequirementsId: "force_users"}
+	form    = &pb.FormMetadata{FormId: "simple_form", Version: 1, FormKey: 5, ResourceName: "deploy.form"}
+
+	deployment = DeploymentResult{&pb.DeployResourceResponse{
+		Key: 10,
+		Deployments: []*pb.Deployment{
+			{Metadata: &pb.Deployment_Process{Process: process}},
+			{Metadata: &pb.Deployment_Decision{Decision: jedi}},
+			{Metadata: &pb.Deployment_DecisionRequirements{DecisionRequirements: drg}},
+			{Metadata: &pb.Deployment_Process{Process: other}},
+			{Metadata: &pb.Deployment_Form{Form: form}},
+		},
+	}}
+)
+
+func TestDeploymentResult_ByType(t *testing.T) {
+	if diff := cmp.Diff([]*pb.ProcessMetadata{process, other}, deployment.Processes(), protocmp.Transform()); diff != "" {
+		t.Errorf("Processes() mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]*pb.DecisionMetadata{jedi}, deployment.Decisions(), protocmp.Transform()); diff != "" {
+		t.Errorf("Decisions() mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]*pb.DecisionRequirementsMetadata{drg}, deployment.DRGs(), protocmp.Transform()); diff != "" {
+		t.Errorf("DRGs() mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]*pb.FormMetadata{form}, deployment.Forms(), protocmp.Transform()); diff != "" {
+		t.Errorf("Forms() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestDeploymentResult_ByID(t *testing.T) {
+	if got := deployment.ProcessByID("other"); got != other {
+		t.Errorf("ProcessByID(\"other\") = %v, want %v", got, other)
+	}
+	if got := deployment.DecisionByID("jedi_or_sith"); got != jedi {
+		t.Errorf("DecisionByID(\"jedi_or_sith\") = %v, want %v", got, jedi)
+	}
+	if got := deployment.DRGByID("force_users"); got != drg {
+		t.Errorf("DRGByID(\"force_users\") = %v, want %v", got, drg)
+	}
+	if got := deployment.FormByID("simple_form"); got != form {
+		t.Errorf("FormByID(\"simple_form\") = %v, want %v", got, form)
+	}
+	if got := deployment.ProcessByID("missing"); got != nil {
+		t.Errorf("ProcessByID(\"missing\") = %v, want nil", got)
+	}
+}
+
+func TestDeploymentResult_Empty(t *testing.T) {
+	empty := DeploymentResult{&pb.DeployResourceResponse{}}
+	if len(empty.Processes()) != 0 || len(empty.Decisions()) != 0 || len(empty.DRGs()) != 0 || len(empty.Forms()) != 0 {
+		t.Error("Expected an empty deployment to contain nothing")
+	}
+	if got := empty.FormByID("simple_form"); got != nil {
+		t.Errorf("FormByID(\"simple_form\") = %v, want nil", got)
+	}
+}
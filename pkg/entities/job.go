@@ -16,6 +16,8 @@ package entities
 
 import (
 	"encoding/json"
+	"strings"
+	"time"
 
 	"github.com/camunda-community-hub/zeebe-client-go/v8/pkg/pb"
 )
@@ -24,10 +26,17 @@ import (
 //
 // See https://docs.camunda.io/docs/product-manuals/concepts/job-workers/#job-queueing for details
 // on jobs.
+//
+// To read single variables without parsing all of them on every call, see NewJobVariables.
 type Job struct {
 	*pb.ActivatedJob
 }
 
+// DeadlineTime returns the time at which the job times out, unless it is completed or failed before.
+func (j *Job) DeadlineTime() time.Time {
+	return time.UnixMilli(j.GetDeadline())
+}
+
 // GetVariablesAsMap returns a map of a process instance's variables.
 //
 // See https://docs.camunda.io/docs/product-manuals/concepts/variables for details on process
@@ -38,11 +47,19 @@ func (j *Job) GetVariablesAsMap() (map[string]interface{}, error) {
 }
 
 // GetVariablesAs unmarshals the JSON representation of a process instance's
-// variables into type t.
+// variables into type t, with encoding/json whatever the configured Serializer; use NewJobVariables to decode them
+// with a Serializer.
+//
+// Variables offloaded to a claim check store, which job workers resolve before calling their handler, make it return a
+// *VariableError wrapping ErrUnresolvedClaimCheck; read such jobs with NewJobVariables.
 //
 // See https://docs.camunda.io/docs/product-manuals/concepts/variables for details on process
 // variables.
 func (j *Job) GetVariablesAs(t interface{}) error {
+	if name, ok := unresolvedClaimCheck(j.Variables); ok {
+		return &VariableError{Name: name, Err: ErrUnresolvedClaimCheck}
+	}
+
 	return json.Unmarshal([]byte(j.Variables), t)
 }
 
@@ -63,3 +80,29 @@ func (j *Job) GetCustomHeadersAsMap() (map[string]string, error) {
 func (j *Job) GetCustomHeadersAs(t interface{}) error {
 	return json.Unmarshal([]byte(j.CustomHeaders), t)
 }
+
+// GetCustomHeader returns the value of the custom header with the given key, and whether the header exists. The
+// custom headers are parsed on every call, use GetCustomHeadersAsMap to read many of them.
+func (j *Job) GetCustomHeader(key string) (string, bool) {
+	if strings.TrimSpace(j.GetCustomHeaders()) == "" {
+		return "", false
+	}
+
+	headers, err := j.GetCustomHeadersAsMap()
+	if err != nil {
+		return "", false
+	}
+
+	value, ok := headers[key]
+	return value, ok
+}
+
+// GetCustomHeaderOrDefault returns the value of the custom header with the given key, or defaultValue if the job has
+// no such header.
+func (j *Job) GetCustomHeaderOrDefault(key, defaultValue string) string {
+	if value, ok := j.GetCustomHeader(key); ok {
+		return value
+	}
+
+	return defaultValue
+}
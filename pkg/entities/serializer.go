@@ -0,0 +1,50 @@
+// Copyright © 2018 Camunda Services GmbH (info@camunda.com)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package entities
+
+import (
+	"encoding/json"
+)
+
+// Serializer encodes variables sent to the gateway and decodes the variables of activated jobs read through
+// JobVariables, but not through Job.GetVariablesAs, which always uses encoding/json. Implementations must
+// produce and accept JSON, as the gateway exchanges variables as JSON documents, but may use faster libraries than
+// encoding/json, e.g. jsoniter or sonic, or custom codecs for types like decimals or time.Time.
+type Serializer interface {
+	Marshal(v interface{}) ([]byte, error)
+	Unmarshal(data []byte, v interface{}) error
+}
+
+// JSONSerializer is the default Serializer, based on encoding/json
+var JSONSerializer Serializer = jsonSerializer{}
+
+type jsonSerializer struct{}
+
+func (jsonSerializer) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (jsonSerializer) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+// serializerOrDefault returns the serializer, or JSONSerializer if it is nil
+func serializerOrDefault(serializer Serializer) Serializer {
+	if serializer == nil {
+		return JSONSerializer
+	}
+
+	return serializer
+}
@@ -0,0 +1,268 @@
+// Copyright © 2018 Camunda Services GmbH (info@camunda.com)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package entities
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrVariableNotFound is wrapped by the VariableError returned when a job has no variable with the requested name, or
+// when a JSON pointer does not resolve to a value.
+var ErrVariableNotFound = errors.New("variable not found")
+
+// pointerTokenUnescaper unescapes the reference tokens of a JSON pointer
+var pointerTokenUnescaper = strings.NewReplacer("~1", "/", "~0", "~")
+
+// VariableError is returned when a job variable cannot be read. Name is the name of the variable, or the JSON pointer
+// used to look it up.
+type VariableError struct {
+	Name string
+	Err  error
+}
+
+func (e *VariableError) Error() string {
+	return fmt.Sprintf("variable '%s': %s", e.Name, e.Err)
+}
+
+func (e *VariableError) Unwrap() error {
+	return e.Err
+}
+
+// JobVariables reads the variables of a job. The variables are parsed once, on first use, and each variable is only
+// unmarshalled when it is requested, so reading several variables is cheap. The job itself is not modified: a
+// JobVariables belongs to the caller which created it, and must not be used by several goroutines at the same time.
+type JobVariables struct {
+	raw        string
+	serializer Serializer
+	claimCheck *ClaimCheck
+
+	parsed bool
+	values map[string]json.RawMessage
+	err    error
+}
+
+// NewJobVariables returns the variables of the job, decoded with the serializer, or JSONSerializer if it is nil. If
+// the claim check is not nil, the variables it offloaded are resolved when they are read.
+//
+//	variables := entities.NewJobVariables(job, nil, nil)
+//	orderID, err := entities.GetVariable[string](variables, "orderId")
+func NewJobVariables(job Job, serializer Serializer, claimCheck *ClaimCheck) *JobVariables {
+	return &JobVariables{raw: job.GetVariables(), serializer: serializerOrDefault(serializer), claimCheck: claimCheck}
+}
+
+// parse returns the top level variables, parsing them on first use
+func (v *JobVariables) parse() (map[string]json.RawMessage, error) {
+	if !v.parsed {
+		if strings.TrimSpace(v.raw) != "" {
+			v.err = v.serializer.Unmarshal([]byte(v.raw), &v.values)
+		}
+		v.parsed = true
+	}
+
+	return v.values, v.err
+}
+
+// Resolve fetches the values of the given variables, or of all variables if no name is given, which were offloaded to
+// the claim check store, so that reading them afterwards does not block. Otherwise, offloaded values are fetched when
+// they are first read, without a deadline.
+func (v *JobVariables) Resolve(ctx context.Context, names ...string) error {
+	values, err := v.parse()
+	if err != nil {
+		return err
+	}
+
+	if len(names) == 0 {
+		for name := range values {
+			names = append(names, name)
+		}
+	}
+
+	for _, name := range names {
+		if _, ok := values[name]; !ok {
+			continue
+		}
+
+		if _, err := v.resolve(ctx, name); err != nil {
+			return &VariableError{Name: name, Err: err}
+		}
+	}
+
+	return nil
+}
+
+// resolve returns the JSON value of the variable, fetching it from the claim check store if it was offloaded. Resolved
+// values replace the references in the JobVariables, so each value is only fetched once.
+func (v *JobVariables) resolve(ctx context.Context, name string) (json.RawMessage, error) {
+	value := v.values[name]
+	if v.claimCheck == nil {
+		return value, nil
+	}
+
+	resolved, err := v.claimCheck.Resolve(ctx, value)
+	if err != nil {
+		return nil, err
+	}
+
+	v.values[name] = resolved
+	return resolved, nil
+}
+
+// Has returns true if the job has a variable with the given name.
+func (v *JobVariables) Has(name string) bool {
+	values, err := v.parse()
+	if err != nil {
+		return false
+	}
+
+	_, ok := values[name]
+	return ok
+}
+
+// As unmarshals all variables into type t, like Job.GetVariablesAs, but with the serializer and claim check of the
+// JobVariables. Offloaded values not fetched by Resolve before are fetched without a deadline.
+func (v *JobVariables) As(t interface{}) error {
+	if v.claimCheck == nil {
+		return v.serializer.Unmarshal([]byte(v.raw), t)
+	}
+
+	if err := v.Resolve(context.Background()); err != nil {
+		return err
+	}
+
+	b, err := json.Marshal(v.values)
+	if err != nil {
+		return err
+	}
+	return v.serializer.Unmarshal(b, t)
+}
+
+// GetVariable unmarshals the variable with the given name into type T. If the variable does not exist or cannot be
+// unmarshalled into T, a *VariableError naming the variable is returned. An offloaded value not fetched by
+// JobVariables.Resolve before is fetched without a deadline.
+//
+//	orderID, err := entities.GetVariable[string](variables, "orderId")
+func GetVariable[T any](variables *JobVariables, name string) (T, error) {
+	var value T
+
+	values, err := variables.parse()
+	if err != nil {
+		return value, &VariableError{Name: name, Err: err}
+	}
+
+	if _, ok := values[name]; !ok {
+		return value, &VariableError{Name: name, Err: ErrVariableNotFound}
+	}
+
+	raw, err := variables.resolve(context.Background(), name)
+	if err != nil {
+		return value, &VariableError{Name: name, Err: err}
+	}
+
+	if err := variables.serializer.Unmarshal(raw, &value); err != nil {
+		return value, &VariableError{Name: name, Err: err}
+	}
+
+	return value, nil
+}
+
+// GetVariableOrDefault is like GetVariable, but returns defaultValue if the variable does not exist.
+func GetVariableOrDefault[T any](variables *JobVariables, name string, defaultValue T) (T, error) {
+	value, err := GetVariable[T](variables, name)
+	if errors.Is(err, ErrVariableNotFound) {
+		return defaultValue, nil
+	}
+
+	return value, err
+}
+
+// GetVariableAt unmarshals the value at the given JSON pointer (RFC 6901) into type T. The first reference token of
+// the pointer is the variable name, e.g. "/order/items/0/price" reads the price of the first item of the order
+// variable.
+func GetVariableAt[T any](variables *JobVariables, pointer string) (T, error) {
+	var value T
+
+	if !strings.HasPrefix(pointer, "/") {
+		return value, &VariableError{Name: pointer, Err: errors.New("JSON pointer must start with '/'")}
+	}
+
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = pointerTokenUnescaper.Replace(token)
+	}
+
+	values, err := variables.parse()
+	if err != nil {
+		return value, &VariableError{Name: pointer, Err: err}
+	}
+
+	if _, ok := values[tokens[0]]; !ok {
+		return value, &VariableError{Name: pointer, Err: ErrVariableNotFound}
+	}
+
+	raw, err := variables.resolve(context.Background(), tokens[0])
+	if err != nil {
+		return value, &VariableError{Name: pointer, Err: err}
+	}
+
+	for _, token := range tokens[1:] {
+		raw, err = lookupJSON(raw, token)
+		if err != nil {
+			return value, &VariableError{Name: pointer, Err: err}
+		}
+	}
+
+	if err := variables.serializer.Unmarshal(raw, &value); err != nil {
+		return value, &VariableError{Name: pointer, Err: err}
+	}
+
+	return value, nil
+}
+
+// lookupJSON returns the member of the JSON object, or the element of the JSON array, referenced by the token
+func lookupJSON(raw json.RawMessage, token string) (json.RawMessage, error) {
+	trimmed := bytes.TrimSpace(raw)
+	switch {
+	case len(trimmed) > 0 && trimmed[0] == '{':
+		var object map[string]json.RawMessage
+		if err := json.Unmarshal(trimmed, &object); err != nil {
+			return nil, err
+		}
+
+		member, ok := object[token]
+		if !ok {
+			return nil, ErrVariableNotFound
+		}
+		return member, nil
+	case len(trimmed) > 0 && trimmed[0] == '[':
+		var array []json.RawMessage
+		if err := json.Unmarshal(trimmed, &array); err != nil {
+			return nil, err
+		}
+
+		index, err := strconv.Atoi(token)
+		if err != nil || index < 0 || index >= len(array) || (len(token) > 1 && token[0] == '0') {
+			return nil, ErrVariableNotFound
+		}
+		return array[index], nil
+	default:
+		return nil, ErrVariableNotFound
+	}
+}
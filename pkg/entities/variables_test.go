@@ -0,0 +1,198 @@
+// Copyright © 2018 Camunda Services GmbH (info@camunda.com)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package entities
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/camunda-community-hub/zeebe-client-go/v8/pkg/pb"
+	"github.com/google/go-cmp/cmp"
+)
+
+func newVariablesJob() *Job {
+	return &Job{ActivatedJob: &pb.ActivatedJob{
+		Variables:     `{"orderId": "order-1", "total": 42.5, "express": true, "order": {"items": [{"sku": "a/b"}, {"sku": "c~d"}]}, "nothing": null}`,
+		CustomHeaders: `{"priority": "high"}`,
+		Deadline:      1700000000123,
+	}}
+}
+
+func TestGetVariable(t *testing.T) {
+	variables := NewJobVariables(*newVariablesJob(), nil, nil)
+
+	orderID, err := GetVariable[string](variables, "orderId")
+	if err != nil || orderID != "order-1" {
+		t.Errorf("GetVariable[string](variables, \"orderId\") = %q, %v", orderID, err)
+	}
+
+	total, err := GetVariable[float64](variables, "total")
+	if err != nil || total != 42.5 {
+		t.Errorf("GetVariable[float64](variables, \"total\") = %v, %v", total, err)
+	}
+
+	type item struct {
+		Sku string
+	}
+	items, err := GetVariable[map[string][]item](variables, "order")
+	if diff := cmp.Diff(map[string][]item{"items": {{"a/b"}, {"c~d"}}}, items); diff != "" || err != nil {
+		t.Errorf("GetVariable(variables, \"order\") differs (-want +got):\n%s, %v", diff, err)
+	}
+}
+
+func TestJobVariablesParseVariablesOnce(t *testing.T) {
+	job := newVariablesJob()
+	variables := NewJobVariables(*job, nil, nil)
+
+	if _, err := GetVariable[string](variables, "orderId"); err != nil {
+		t.Fatalf("GetVariable() = %v", err)
+	}
+	variables.values["express"] = []byte("false")
+
+	if express, err := GetVariable[bool](variables, "express"); err != nil || express {
+		t.Errorf("expected variables to be parsed once, got %v, %v", express, err)
+	}
+
+	job.Variables = `{"orderId": "order-2"}`
+	if orderID, err := GetVariable[string](variables, "orderId"); err != nil || orderID != "order-1" {
+		t.Errorf("expected the variables read first to be kept, got %q, %v", orderID, err)
+	}
+	if orderID, err := GetVariable[string](NewJobVariables(*job, nil, nil), "orderId"); err != nil || orderID != "order-2" {
+		t.Errorf("expected new variables to read the changed job, got %q, %v", orderID, err)
+	}
+}
+
+func TestGetVariableErrorNamesVariable(t *testing.T) {
+	variables := NewJobVariables(*newVariablesJob(), nil, nil)
+
+	_, err := GetVariable[string](variables, "missing")
+	var variableErr *VariableError
+	if !errors.As(err, &variableErr) || variableErr.Name != "missing" || !errors.Is(err, ErrVariableNotFound) {
+		t.Errorf("GetVariable(variables, \"missing\") = %v, want not found error naming the variable", err)
+	}
+
+	_, err = GetVariable[int](variables, "orderId")
+	if !errors.As(err, &variableErr) || variableErr.Name != "orderId" || errors.Is(err, ErrVariableNotFound) {
+		t.Errorf("GetVariable[int](variables, \"orderId\") = %v, want type error naming the variable", err)
+	}
+}
+
+func TestGetVariableOrDefault(t *testing.T) {
+	variables := NewJobVariables(*newVariablesJob(), nil, nil)
+
+	if value, err := GetVariableOrDefault(variables, "missing", 7); err != nil || value != 7 {
+		t.Errorf("GetVariableOrDefault(variables, \"missing\", 7) = %v, %v", value, err)
+	}
+
+	if value, err := GetVariableOrDefault(variables, "express", false); err != nil || !value {
+		t.Errorf("GetVariableOrDefault(variables, \"express\", false) = %v, %v", value, err)
+	}
+}
+
+func TestJobVariables_Has(t *testing.T) {
+	variables := NewJobVariables(*newVariablesJob(), nil, nil)
+
+	if !variables.Has("nothing") || variables.Has("missing") {
+		t.Errorf("variables.Has() does not match the variables of the job")
+	}
+}
+
+func TestGetVariableAt(t *testing.T) {
+	variables := NewJobVariables(*newVariablesJob(), nil, nil)
+
+	tests := []struct {
+		pointer string
+		want    string
+	}{
+		{"/orderId", "order-1"},
+		{"/order/items/0/sku", "a/b"},
+		{"/order/items/1/sku", "c~d"},
+	}
+	for _, test := range tests {
+		got, err := GetVariableAt[string](variables, test.pointer)
+		if err != nil || got != test.want {
+			t.Errorf("GetVariableAt(variables, %q) = %q, %v, want %q", test.pointer, got, err, test.want)
+		}
+	}
+
+	for _, pointer := range []string{"/order/items/2/sku", "/order/items/01", "/order/missing", "/orderId/length", "/missing"} {
+		_, err := GetVariableAt[string](variables, pointer)
+		var variableErr *VariableError
+		if !errors.As(err, &variableErr) || variableErr.Name != pointer || !errors.Is(err, ErrVariableNotFound) {
+			t.Errorf("GetVariableAt(variables, %q) = %v, want not found error naming the pointer", pointer, err)
+		}
+	}
+
+	if _, err := GetVariableAt[string](variables, "orderId"); err == nil {
+		t.Errorf("expected pointer without leading slash to be rejected")
+	}
+}
+
+func TestJob_DeadlineTime(t *testing.T) {
+	job := newVariablesJob()
+
+	if got, want := job.DeadlineTime(), time.UnixMilli(1700000000123); !got.Equal(want) {
+		t.Errorf("job.DeadlineTime() = %v, want %v", got, want)
+	}
+}
+
+func TestJob_GetCustomHeader(t *testing.T) {
+	job := newVariablesJob()
+
+	if value, ok := job.GetCustomHeader("priority"); !ok || value != "high" {
+		t.Errorf("job.GetCustomHeader(\"priority\") = %q, %v", value, ok)
+	}
+
+	if value := job.GetCustomHeaderOrDefault("region", "eu"); value != "eu" {
+		t.Errorf("job.GetCustomHeaderOrDefault(\"region\", \"eu\") = %q", value)
+	}
+}
+
+// prefixSerializer decodes strings with a prefix, to tell it apart from encoding/json
+type prefixSerializer struct{}
+
+func (prefixSerializer) Marshal(v interface{}) ([]byte, error) {
+	return JSONSerializer.Marshal(v)
+}
+
+func (prefixSerializer) Unmarshal(data []byte, v interface{}) error {
+	if s, ok := v.(*string); ok {
+		if err := JSONSerializer.Unmarshal(data, s); err != nil {
+			return err
+		}
+		*s = "decoded:" + *s
+		return nil
+	}
+
+	return JSONSerializer.Unmarshal(data, v)
+}
+
+func TestGetVariableWithSerializer(t *testing.T) {
+	variables := NewJobVariables(*newVariablesJob(), prefixSerializer{}, nil)
+
+	if orderID, err := GetVariable[string](variables, "orderId"); err != nil || orderID != "decoded:order-1" {
+		t.Errorf("GetVariable[string](variables, \"orderId\") = %q, %v", orderID, err)
+	}
+
+	if sku, err := GetVariableAt[string](variables, "/order/items/0/sku"); err != nil || sku != "decoded:a/b" {
+		t.Errorf("GetVariableAt[string](variables, \"/order/items/0/sku\") = %q, %v", sku, err)
+	}
+
+	var orderID string
+	if err := NewJobVariables(Job{&pb.ActivatedJob{Variables: `"order-1"`}}, prefixSerializer{}, nil).As(&orderID); err != nil || orderID != "decoded:order-1" {
+		t.Errorf("variables.As() = %q, %v", orderID, err)
+	}
+}
@@ -0,0 +1,136 @@
+// Copyright © 2018 Camunda Services GmbH (info@camunda.com)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package errors provides errors.Is and errors.As friendly errors for failures returned by the gateway. Every command
+// wraps the gRPC status errors it receives into a *GatewayError, which matches the sentinel error for its status code:
+//
+//	_, err := client.NewCompleteJobCommand().JobKey(key).Send(ctx)
+//	if errors.Is(err, zberrors.ErrNotFound) {
+//		// the job does not exist anymore, e.g. because it was already completed
+//	}
+//
+// The original status is still available through status.Code and status.FromError.
+package errors
+
+import (
+	"errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type Error string
+
+func (e Error) Error() string {
+	return string(e)
+}
+
+const (
+	// ErrCanceled is matched by calls which were canceled, usually by the caller
+	ErrCanceled = Error("canceled")
+	// ErrInvalidArgument is matched by calls which were rejected because of an invalid request, e.g. malformed variables
+	ErrInvalidArgument = Error("invalid argument")
+	// ErrDeadlineExceeded is matched by calls which did not complete before their deadline
+	ErrDeadlineExceeded = Error("deadline exceeded")
+	// ErrNotFound is matched by calls referring to an entity which does not exist, e.g. a job which was already
+	// completed or a process which was not deployed
+	ErrNotFound = Error("not found")
+	// ErrAlreadyExists is matched by calls creating an entity which already exists, e.g. a message published with an
+	// ID which is already in use
+	ErrAlreadyExists = Error("already exists")
+	// ErrPermissionDenied is matched by calls the client is not authorized to make
+	ErrPermissionDenied = Error("permission denied")
+	// ErrResourceExhausted is matched by calls which the gateway rejected because the cluster is under back pressure
+	ErrResourceExhausted = Error("resource exhausted")
+	// ErrFailedPrecondition is matched by calls which are invalid in the current state of an entity, e.g. failing a job
+	// which is not activated
+	ErrFailedPrecondition = Error("failed precondition")
+	// ErrUnimplemented is matched by calls which the gateway does not support
+	ErrUnimplemented = Error("unimplemented")
+	// ErrInternal is matched by calls which failed because of an internal error in the cluster
+	ErrInternal = Error("internal error")
+	// ErrUnavailable is matched by calls which failed because the gateway or cluster is not available, and which can
+	// usually be retried
+	ErrUnavailable = Error("unavailable")
+	// ErrUnauthenticated is matched by calls which were rejected because of missing or invalid credentials
+	ErrUnauthenticated = Error("unauthenticated")
+)
+
+var sentinels = map[codes.Code]Error{
+	codes.Canceled:           ErrCanceled,
+	codes.InvalidArgument:    ErrInvalidArgument,
+	codes.DeadlineExceeded:   ErrDeadlineExceeded,
+	codes.NotFound:           ErrNotFound,
+	codes.AlreadyExists:      ErrAlreadyExists,
+	codes.PermissionDenied:   ErrPermissionDenied,
+	codes.ResourceExhausted:  ErrResourceExhausted,
+	codes.FailedPrecondition: ErrFailedPrecondition,
+	codes.Unimplemented:      ErrUnimplemented,
+	codes.Internal:           ErrInternal,
+	codes.Unavailable:        ErrUnavailable,
+	codes.Unauthenticated:    ErrUnauthenticated,
+}
+
+// GatewayError is a failure reported by the gateway. It matches the sentinel error for its status code with
+// errors.Is, and unwraps to the original gRPC error.
+type GatewayError struct {
+	status *status.Status
+	err    error
+}
+
+func (e *GatewayError) Error() string {
+	return e.err.Error()
+}
+
+func (e *GatewayError) Unwrap() error {
+	return e.err
+}
+
+func (e *GatewayError) Is(target error) bool {
+	sentinel, ok := sentinels[e.status.Code()]
+	return ok && target == sentinel
+}
+
+// GRPCStatus returns the status of the call, which keeps status.Code and status.FromError working on wrapped errors
+func (e *GatewayError) GRPCStatus() *status.Status {
+	return e.status
+}
+
+// Code returns the status code of the call
+func (e *GatewayError) Code() codes.Code {
+	return e.status.Code()
+}
+
+// Message returns the message sent by the gateway, which describes the failure in more detail
+func (e *GatewayError) Message() string {
+	return e.status.Message()
+}
+
+// Wrap wraps gRPC status errors into a *GatewayError, and returns any other error, including nil, as it is
+func Wrap(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var gatewayErr *GatewayError
+	if errors.As(err, &gatewayErr) {
+		return err
+	}
+
+	s, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+
+	return &GatewayError{status: s, err: err}
+}
@@ -0,0 +1,77 @@
+// Copyright © 2018 Camunda Services GmbH (info@camunda.com)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package errors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"testing"
+)
+
+func TestWrapMatchesSentinelOfStatusCode(t *testing.T) {
+	for code, sentinel := range sentinels {
+		t.Run(code.String(), func(t *testing.T) {
+			// given
+			cause := status.Error(code, "failure")
+
+			// when
+			err := Wrap(cause)
+
+			// then
+			require.ErrorIs(t, err, sentinel)
+			require.ErrorIs(t, err, cause)
+			require.Equal(t, code, status.Code(err))
+			for _, other := range sentinels {
+				if other != sentinel {
+					require.NotErrorIs(t, err, other)
+				}
+			}
+
+			var gatewayErr *GatewayError
+			require.ErrorAs(t, err, &gatewayErr)
+			require.Equal(t, code, gatewayErr.Code())
+			require.Equal(t, "failure", gatewayErr.Message())
+		})
+	}
+}
+
+func TestWrapStatusWithoutSentinel(t *testing.T) {
+	err := Wrap(status.Error(codes.DataLoss, "failure"))
+
+	var gatewayErr *GatewayError
+	require.ErrorAs(t, err, &gatewayErr)
+	require.Equal(t, codes.DataLoss, status.Code(err))
+	for _, sentinel := range sentinels {
+		require.NotErrorIs(t, err, sentinel)
+	}
+}
+
+func TestWrapIgnoresOtherErrors(t *testing.T) {
+	require.NoError(t, Wrap(nil))
+	require.Equal(t, context.Canceled, Wrap(context.Canceled))
+}
+
+func TestWrapIsIdempotent(t *testing.T) {
+	err := Wrap(status.Error(codes.AlreadyExists, "message already published"))
+	wrapped := fmt.Errorf("publish failed: %w", err)
+
+	require.Same(t, err, Wrap(err))
+	require.Equal(t, wrapped, Wrap(wrapped))
+	require.True(t, errors.Is(Wrap(wrapped), ErrAlreadyExists))
+}
@@ -0,0 +1,346 @@
+// Copyright © 2018 Camunda Services GmbH (info@camunda.com)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	// BPMNNamespace is the XML namespace of BPMN 2.0 model elements
+	BPMNNamespace = "http://www.omg.org/spec/BPMN/20100524/MODEL"
+	// ZeebeNamespace is the XML namespace of the Zeebe extension elements
+	ZeebeNamespace = "http://camunda.org/schema/zeebe/1.0"
+)
+
+// flowNodeTypes are the local names of the BPMN elements which can be connected by sequence flows
+var flowNodeTypes = map[string]bool{
+	"startEvent":             true,
+	"endEvent":               true,
+	"intermediateCatchEvent": true,
+	"intermediateThrowEvent": true,
+	"boundaryEvent":          true,
+	"task":                   true,
+	"serviceTask":            true,
+	"sendTask":               true,
+	"receiveTask":            true,
+	"userTask":               true,
+	"manualTask":             true,
+	"businessRuleTask":       true,
+	"scriptTask":             true,
+	"callActivity":           true,
+	"subProcess":             true,
+	"adHocSubProcess":        true,
+	"transaction":            true,
+	"exclusiveGateway":       true,
+	"parallelGateway":        true,
+	"inclusiveGateway":       true,
+	"eventBasedGateway":      true,
+	"complexGateway":         true,
+}
+
+// scopeTypes are the flow node types containing flow elements of their own
+var scopeTypes = map[string]bool{
+	"subProcess":      true,
+	"adHocSubProcess": true,
+	"transaction":     true,
+}
+
+// Definitions is the content of a BPMN file
+type Definitions struct {
+	ID        string
+	Processes []*Process
+
+	root *element
+}
+
+// Process is a process of a BPMN file, with the flow nodes and sequence flows of all its scopes
+type Process struct {
+	ID         string
+	Name       string
+	Executable bool
+	// FlowNodes are the flow nodes of the process, including the ones nested in sub-processes, in document order
+	FlowNodes     []*FlowNode
+	SequenceFlows []*SequenceFlow
+}
+
+// FlowNode is an event, activity or gateway of a process
+type FlowNode struct {
+	ID   string
+	Name string
+	// Type is the local name of the BPMN element, e.g. serviceTask or exclusiveGateway
+	Type string
+	// ProcessID is the id of the process containing the flow node
+	ProcessID string
+	// Scope is the id of the process or sub-process containing the flow node
+	Scope string
+	// TaskType is the type of the zeebe:taskDefinition extension element, empty if there is none. Like TaskRetries,
+	// it is an expression if it starts with '='.
+	TaskType string
+	// TaskRetries are the retries of the zeebe:taskDefinition extension element, empty if the default is used
+	TaskRetries string
+	// TaskHeaders are the static headers of the zeebe:taskHeaders extension element, nil if there are none
+	TaskHeaders map[string]string
+
+	element *element
+}
+
+// SequenceFlow connects two flow nodes of the same scope
+type SequenceFlow struct {
+	ID        string
+	Scope     string
+	SourceRef string
+	TargetRef string
+}
+
+// ParseBPMN parses the content of a BPMN file. It only fails if the content is not a BPMN 2.0 document, use Validate
+// to check its structure.
+func ParseBPMN(content []byte) (*Definitions, error) {
+	root, err := parseXML(content)
+	if err != nil {
+		return nil, err
+	}
+
+	if root.XMLName.Space != BPMNNamespace || root.XMLName.Local != "definitions" {
+		return nil, errors.New("not a BPMN 2.0 document, the root element must be definitions")
+	}
+
+	definitions := &Definitions{ID: root.id(), root: root}
+	for i := range root.Children {
+		e := &root.Children[i]
+		if e.XMLName.Space == BPMNNamespace && e.XMLName.Local == "process" {
+			process := &Process{ID: e.id(), Name: e.attr("name"), Executable: e.attr("isExecutable") == "true"}
+			process.addFlowElements(e, process.ID)
+			definitions.Processes = append(definitions.Processes, process)
+		}
+	}
+
+	return definitions, nil
+}
+
+func (p *Process) addFlowElements(scope *element, scopeID string) {
+	for i := range scope.Children {
+		e := &scope.Children[i]
+		if e.XMLName.Space != BPMNNamespace {
+			continue
+		}
+
+		switch {
+		case e.XMLName.Local == "sequenceFlow":
+			p.SequenceFlows = append(p.SequenceFlows, &SequenceFlow{
+				ID:        e.id(),
+				Scope:     scopeID,
+				SourceRef: e.attr("sourceRef"),
+				TargetRef: e.attr("targetRef"),
+			})
+		case flowNodeTypes[e.XMLName.Local]:
+			node := &FlowNode{
+				ID:        e.id(),
+				Name:      e.attr("name"),
+				Type:      e.XMLName.Local,
+				ProcessID: p.ID,
+				Scope:     scopeID,
+				element:   e,
+			}
+			if taskDefinition := node.extension("taskDefinition"); taskDefinition != nil {
+				node.TaskType = taskDefinition.attr("type")
+				node.TaskRetries = taskDefinition.attr("retries")
+			}
+			if taskHeaders := node.extension("taskHeaders"); taskHeaders != nil {
+				node.TaskHeaders = make(map[string]string)
+				for _, header := range taskHeaders.Children {
+					if header.XMLName.Space == ZeebeNamespace && header.XMLName.Local == "header" {
+						node.TaskHeaders[header.attr("key")] = header.attr("value")
+					}
+				}
+			}
+			p.FlowNodes = append(p.FlowNodes, node)
+
+			if scopeTypes[e.XMLName.Local] {
+				p.addFlowElements(e, node.ID)
+			}
+		}
+	}
+}
+
+// extension returns the Zeebe extension element with the given local name, or nil
+func (n *FlowNode) extension(local string) *element {
+	extensionElements := n.element.child(BPMNNamespace, "extensionElements")
+	if extensionElements == nil {
+		return nil
+	}
+
+	return extensionElements.child(ZeebeNamespace, local)
+}
+
+func validateBPMN(content []byte) []Problem {
+	definitions, err := ParseBPMN(content)
+	if err != nil {
+		return []Problem{{Message: err.Error()}}
+	}
+
+	return definitions.validate()
+}
+
+func (d *Definitions) validate() []Problem {
+	problems := duplicateIDs(d.root)
+
+	executable := false
+	for _, process := range d.Processes {
+		executable = executable || process.Executable
+	}
+	if !executable {
+		problems = append(problems, Problem{Message: "contains no executable process"})
+	}
+
+	problems = append(problems, d.unknownRootElementReferences()...)
+	for _, process := range d.Processes {
+		problems = append(problems, process.validate()...)
+	}
+
+	return problems
+}
+
+// unknownRootElementReferences reports event definitions referencing messages, signals, errors or escalations which
+// are not defined
+func (d *Definitions) unknownRootElementReferences() []Problem {
+	rootElements := make(map[string]string)
+	for _, e := range d.root.Children {
+		if e.XMLName.Space == BPMNNamespace && e.id() != "" {
+			rootElements[e.id()] = e.XMLName.Local
+		}
+	}
+
+	references := map[string]string{
+		"messageEventDefinition":    "message",
+		"signalEventDefinition":     "signal",
+		"errorEventDefinition":      "error",
+		"escalationEventDefinition": "escalation",
+	}
+
+	var problems []Problem
+	for _, process := range d.Processes {
+		for _, node := range process.FlowNodes {
+			for _, e := range node.element.Children {
+				referenced, ok := references[e.XMLName.Local]
+				if e.XMLName.Space != BPMNNamespace || !ok {
+					continue
+				}
+
+				ref := e.attr(referenced + "Ref")
+				if ref != "" && rootElements[ref] != referenced {
+					problems = append(problems, Problem{
+						Element: node.ID,
+						Message: fmt.Sprintf("references unknown %s '%s'", referenced, ref),
+					})
+				}
+			}
+
+			// receive tasks reference their message directly
+			if ref := node.element.attr("messageRef"); ref != "" && rootElements[ref] != "message" {
+				problems = append(problems, Problem{Element: node.ID, Message: fmt.Sprintf("references unknown message '%s'", ref)})
+			}
+		}
+	}
+
+	return problems
+}
+
+func (p *Process) validate() []Problem {
+	var problems []Problem
+
+	nodes := make(map[string]*FlowNode, len(p.FlowNodes))
+	scopeSizes := make(map[string]int)
+	for _, node := range p.FlowNodes {
+		nodes[node.ID] = node
+		scopeSizes[node.Scope]++
+	}
+
+	connected := make(map[string]bool)
+	outgoing := make(map[string]map[string]bool)
+	for _, flow := range p.SequenceFlows {
+		for _, end := range []struct{ kind, ref string }{{"source", flow.SourceRef}, {"target", flow.TargetRef}} {
+			node, ok := nodes[end.ref]
+			switch {
+			case end.ref == "":
+				problems = append(problems, Problem{Element: flow.ID, Message: fmt.Sprintf("sequence flow has no %s", end.kind)})
+			case !ok:
+				problems = append(problems, Problem{
+					Element: flow.ID,
+					Message: fmt.Sprintf("sequence flow references unknown %s '%s'", end.kind, end.ref),
+				})
+			case node.Scope != flow.Scope:
+				problems = append(problems, Problem{
+					Element: flow.ID,
+					Message: fmt.Sprintf("sequence flow %s '%s' is not in the same scope", end.kind, end.ref),
+				})
+			default:
+				connected[end.ref] = true
+			}
+		}
+
+		if outgoing[flow.SourceRef] == nil {
+			outgoing[flow.SourceRef] = make(map[string]bool)
+		}
+		outgoing[flow.SourceRef][flow.ID] = true
+	}
+
+	for _, node := range p.FlowNodes {
+		if node.Type == "serviceTask" && node.TaskType == "" {
+			problems = append(problems, Problem{Element: node.ID, Message: "service task has no zeebe:taskDefinition type"})
+		}
+
+		if ref := node.element.attr("attachedToRef"); node.Type == "boundaryEvent" {
+			if attached, ok := nodes[ref]; !ok || !isActivity(attached.Type) {
+				problems = append(problems, Problem{
+					Element: node.ID,
+					Message: fmt.Sprintf("boundary event is attached to unknown activity '%s'", ref),
+				})
+			}
+		}
+
+		if ref := node.element.attr("default"); ref != "" && !outgoing[node.ID][ref] {
+			problems = append(problems, Problem{
+				Element: node.ID,
+				Message: fmt.Sprintf("default flow '%s' is not an outgoing sequence flow", ref),
+			})
+		}
+
+		if !connected[node.ID] && scopeSizes[node.Scope] > 1 && !node.isUnconnectedByDesign() && !p.isAdHocScope(node.Scope, nodes) {
+			problems = append(problems, Problem{Element: node.ID, Message: "flow node is not connected by any sequence flow"})
+		}
+	}
+
+	return problems
+}
+
+// isUnconnectedByDesign returns true for flow nodes which are not expected to be connected by sequence flows
+func (n *FlowNode) isUnconnectedByDesign() bool {
+	return n.Type == "boundaryEvent" ||
+		n.element.attr("triggeredByEvent") == "true" ||
+		n.element.attr("isForCompensation") == "true"
+}
+
+func (p *Process) isAdHocScope(scope string, nodes map[string]*FlowNode) bool {
+	node, ok := nodes[scope]
+	return ok && node.Type == "adHocSubProcess"
+}
+
+// isActivity returns true for tasks, sub-processes and call activities, the flow nodes boundary events can be attached to
+func isActivity(nodeType string) bool {
+	return !strings.HasSuffix(nodeType, "Event") && !strings.HasSuffix(nodeType, "Gateway")
+}
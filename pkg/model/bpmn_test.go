@@ -0,0 +1,149 @@
+// Copyright © 2018 Camunda Services GmbH (info@camunda.com)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const bpmnTemplate = `<?xml version="1.0" encoding="UTF-8"?>
+<bpmn:definitions xmlns:bpmn="http://www.omg.org/spec/BPMN/20100524/MODEL" xmlns:zeebe="http://camunda.org/schema/zeebe/1.0" id="definitions">
+  <bpmn:message id="message" name="order" />
+  <bpmn:process id="process" isExecutable="true">
+    %s
+  </bpmn:process>
+</bpmn:definitions>`
+
+func bpmn(process string) []byte {
+	return []byte(fmt.Sprintf(bpmnTemplate, process))
+}
+
+func requireProblems(t *testing.T, err error, expected ...Problem) {
+	t.Helper()
+
+	var validationErr *ValidationError
+	require.True(t, errors.As(err, &validationErr), "expected a validation error, got %v", err)
+	require.ElementsMatch(t, expected, validationErr.Problems)
+}
+
+func TestParseBPMN(t *testing.T) {
+	content, err := os.ReadFile("testdata/service_task.bpmn")
+	require.NoError(t, err)
+
+	definitions, err := ParseBPMN(content)
+
+	require.NoError(t, err)
+	require.Len(t, definitions.Processes, 1)
+	process := definitions.Processes[0]
+	require.Equal(t, "jobProcess", process.ID)
+	require.True(t, process.Executable)
+	require.Len(t, process.FlowNodes, 3)
+	require.Equal(t, "serviceTask", process.FlowNodes[1].Type)
+	require.Equal(t, "jobType", process.FlowNodes[1].TaskType)
+	require.Equal(t, "jobProcess", process.FlowNodes[1].Scope)
+	require.Len(t, process.SequenceFlows, 2)
+}
+
+func TestParseBPMNRejectsOtherDocuments(t *testing.T) {
+	_, err := ParseBPMN([]byte(`<definitions xmlns="https://www.omg.org/spec/DMN/20191111/MODEL/" />`))
+	require.Error(t, err)
+
+	_, err = ParseBPMN([]byte(`not xml`))
+	require.Error(t, err)
+}
+
+func TestValidateValidBPMN(t *testing.T) {
+	content, err := os.ReadFile("testdata/service_task.bpmn")
+	require.NoError(t, err)
+
+	require.NoError(t, Validate("service_task.bpmn", content))
+}
+
+func TestValidateBPMNDuplicateIDs(t *testing.T) {
+	err := Validate("process.bpmn", bpmn(`
+    <bpmn:startEvent id="start" />
+    <bpmn:endEvent id="start" />
+    <bpmn:sequenceFlow id="flow" sourceRef="start" targetRef="start" />`))
+
+	requireProblems(t, err, Problem{Resource: "process.bpmn", Element: "start", Message: "id is used by more than one element"})
+}
+
+func TestValidateBPMNUnknownReferences(t *testing.T) {
+	err := Validate("process.bpmn", bpmn(`
+    <bpmn:startEvent id="start">
+      <bpmn:messageEventDefinition messageRef="unknown" />
+    </bpmn:startEvent>
+    <bpmn:endEvent id="end" />
+    <bpmn:boundaryEvent id="boundary" attachedToRef="end" />
+    <bpmn:sequenceFlow id="flow" sourceRef="start" targetRef="missing" />
+    <bpmn:sequenceFlow id="other" sourceRef="start" targetRef="end" />`))
+
+	requireProblems(t, err,
+		Problem{Resource: "process.bpmn", Element: "start", Message: "references unknown message 'unknown'"},
+		Problem{Resource: "process.bpmn", Element: "flow", Message: "sequence flow references unknown target 'missing'"},
+		Problem{Resource: "process.bpmn", Element: "boundary", Message: "boundary event is attached to unknown activity 'end'"},
+	)
+}
+
+func TestValidateBPMNServiceTaskWithoutType(t *testing.T) {
+	err := Validate("process.bpmn", bpmn(`
+    <bpmn:startEvent id="start" />
+    <bpmn:serviceTask id="task">
+      <bpmn:extensionElements>
+        <zeebe:taskDefinition type="" />
+      </bpmn:extensionElements>
+    </bpmn:serviceTask>
+    <bpmn:sequenceFlow id="flow" sourceRef="start" targetRef="task" />`))
+
+	requireProblems(t, err, Problem{Resource: "process.bpmn", Element: "task", Message: "service task has no zeebe:taskDefinition type"})
+}
+
+func TestValidateBPMNUnconnectedFlowNodes(t *testing.T) {
+	err := Validate("process.bpmn", bpmn(`
+    <bpmn:startEvent id="start" />
+    <bpmn:endEvent id="end" />
+    <bpmn:endEvent id="unconnected" />
+    <bpmn:sequenceFlow id="flow" sourceRef="start" targetRef="end" />
+    <bpmn:subProcess id="eventSubProcess" triggeredByEvent="true">
+      <bpmn:startEvent id="eventStart">
+        <bpmn:messageEventDefinition messageRef="message" />
+      </bpmn:startEvent>
+    </bpmn:subProcess>
+    <bpmn:subProcess id="subProcess">
+      <bpmn:startEvent id="nestedStart" />
+      <bpmn:endEvent id="nestedEnd" />
+      <bpmn:sequenceFlow id="crossScope" sourceRef="nestedStart" targetRef="end" />
+    </bpmn:subProcess>`))
+
+	requireProblems(t, err,
+		Problem{Resource: "process.bpmn", Element: "unconnected", Message: "flow node is not connected by any sequence flow"},
+		Problem{Resource: "process.bpmn", Element: "subProcess", Message: "flow node is not connected by any sequence flow"},
+		Problem{Resource: "process.bpmn", Element: "crossScope", Message: "sequence flow target 'end' is not in the same scope"},
+		Problem{Resource: "process.bpmn", Element: "nestedEnd", Message: "flow node is not connected by any sequence flow"},
+	)
+}
+
+func TestValidateBPMNWithoutExecutableProcess(t *testing.T) {
+	err := Validate("process.bpmn", []byte(`<bpmn:definitions xmlns:bpmn="http://www.omg.org/spec/BPMN/20100524/MODEL" id="definitions">
+  <bpmn:process id="process" isExecutable="false" />
+</bpmn:definitions>`))
+
+	requireProblems(t, err, Problem{Resource: "process.bpmn", Message: "contains no executable process"})
+}
@@ -0,0 +1,91 @@
+// Copyright © 2018 Camunda Services GmbH (info@camunda.com)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"strings"
+)
+
+// dmnNamespaces are the XML namespaces of the supported DMN versions
+var dmnNamespaces = map[string]bool{
+	"http://www.omg.org/spec/DMN/20151101/dmn.xsd": true,
+	"http://www.omg.org/spec/DMN/20180521/MODEL/":  true,
+	"https://www.omg.org/spec/DMN/20191111/MODEL/": true,
+	"https://www.omg.org/spec/DMN/20211108/MODEL/": true,
+	"https://www.omg.org/spec/DMN/20230324/MODEL/": true,
+}
+
+// requirementReferences maps the DMN requirement elements to the type of element they reference
+var requirementReferences = map[string]string{
+	"requiredDecision":  "decision",
+	"requiredInput":     "inputData",
+	"requiredKnowledge": "businessKnowledgeModel",
+}
+
+func validateDMN(content []byte) []Problem {
+	root, err := parseXML(content)
+	if err != nil {
+		return []Problem{{Message: err.Error()}}
+	}
+
+	namespace := root.XMLName.Space
+	if !dmnNamespaces[namespace] || root.XMLName.Local != "definitions" {
+		return []Problem{{Message: "not a DMN document, the root element must be definitions"}}
+	}
+
+	problems := duplicateIDs(root)
+
+	drgElements := make(map[string]string)
+	for _, e := range root.Children {
+		if e.XMLName.Space == namespace && e.id() != "" {
+			drgElements[e.id()] = e.XMLName.Local
+		}
+	}
+
+	decisions := 0
+	for _, e := range root.Children {
+		if e.XMLName.Space != namespace || e.XMLName.Local != "decision" {
+			continue
+		}
+
+		decisions++
+		if e.id() == "" {
+			problems = append(problems, Problem{Message: "decision has no id"})
+			continue
+		}
+
+		e.walk(func(requirement *element) {
+			referenced, ok := requirementReferences[requirement.XMLName.Local]
+			if requirement.XMLName.Space != namespace || !ok {
+				return
+			}
+
+			href := requirement.attr("href")
+			if ref := strings.TrimPrefix(href, "#"); drgElements[ref] != referenced {
+				problems = append(problems, Problem{
+					Element: e.id(),
+					Message: fmt.Sprintf("requires unknown %s '%s'", referenced, href),
+				})
+			}
+		})
+	}
+
+	if decisions == 0 {
+		problems = append(problems, Problem{Message: "contains no decision"})
+	}
+
+	return problems
+}
@@ -0,0 +1,66 @@
+// Copyright © 2018 Camunda Services GmbH (info@camunda.com)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// form is the part of a form schema which is validated
+type form struct {
+	ID         *string         `json:"id"`
+	Components []formComponent `json:"components"`
+}
+
+type formComponent struct {
+	ID         string          `json:"id"`
+	Type       string          `json:"type"`
+	Components []formComponent `json:"components"`
+}
+
+func validateForm(content []byte) []Problem {
+	var schema form
+	if err := json.Unmarshal(content, &schema); err != nil {
+		return []Problem{{Message: fmt.Sprintf("invalid form JSON: %s", err)}}
+	}
+
+	var problems []Problem
+	if schema.ID == nil || *schema.ID == "" {
+		problems = append(problems, Problem{Message: "form has no id"})
+	}
+
+	seen := make(map[string]bool)
+	var checkComponents func([]formComponent)
+	checkComponents = func(components []formComponent) {
+		for _, component := range components {
+			if component.Type == "" {
+				problems = append(problems, Problem{Element: component.ID, Message: "form component has no type"})
+			}
+
+			if component.ID != "" {
+				if seen[component.ID] {
+					problems = append(problems, Problem{Element: component.ID, Message: "id is used by more than one form component"})
+				}
+				seen[component.ID] = true
+			}
+
+			checkComponents(component.Components)
+		}
+	}
+	checkComponents(schema.Components)
+
+	return problems
+}
@@ -0,0 +1,136 @@
+// Copyright © 2018 Camunda Services GmbH (info@camunda.com)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"sort"
+	"strings"
+)
+
+// Message is a message defined in a BPMN file, referenced by message events and receive tasks
+type Message struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// CorrelationKey is the expression of the zeebe:subscription extension element, empty if there is none
+	CorrelationKey string `json:"correlationKey,omitempty"`
+}
+
+// Signal is a signal defined in a BPMN file, referenced by signal events
+type Signal struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// BPMNError is an error defined in a BPMN file, referenced by error events. Job workers throw it using its code.
+type BPMNError struct {
+	ID   string `json:"id"`
+	Name string `json:"name,omitempty"`
+	Code string `json:"code"`
+}
+
+// JobTasks returns the flow nodes of all processes creating jobs, i.e. having a zeebe:taskDefinition. Besides service
+// tasks, these may be send, script or business rule tasks and message throw events.
+func (d *Definitions) JobTasks() []*FlowNode {
+	var tasks []*FlowNode
+	for _, process := range d.Processes {
+		for _, node := range process.FlowNodes {
+			if node.TaskType != "" {
+				tasks = append(tasks, node)
+			}
+		}
+	}
+
+	return tasks
+}
+
+// JobTypes returns the sorted job types of all processes, which need a job worker. Job types given as expressions are
+// left out, as they are only known at runtime.
+func (d *Definitions) JobTypes() []string {
+	seen := make(map[string]bool)
+	var jobTypes []string
+	for _, task := range d.JobTasks() {
+		if !strings.HasPrefix(task.TaskType, "=") && !seen[task.TaskType] {
+			seen[task.TaskType] = true
+			jobTypes = append(jobTypes, task.TaskType)
+		}
+	}
+
+	sort.Strings(jobTypes)
+	return jobTypes
+}
+
+// MissingJobTypes returns the sorted job types of all processes which are not among the handled job types
+func (d *Definitions) MissingJobTypes(handled ...string) []string {
+	handledTypes := make(map[string]bool, len(handled))
+	for _, jobType := range handled {
+		handledTypes[jobType] = true
+	}
+
+	var missing []string
+	for _, jobType := range d.JobTypes() {
+		if !handledTypes[jobType] {
+			missing = append(missing, jobType)
+		}
+	}
+
+	return missing
+}
+
+// Messages returns the messages defined in the BPMN file, in document order
+func (d *Definitions) Messages() []Message {
+	var messages []Message
+	for _, e := range d.rootElements("message") {
+		message := Message{ID: e.id(), Name: e.attr("name")}
+		if extensionElements := e.child(BPMNNamespace, "extensionElements"); extensionElements != nil {
+			if subscription := extensionElements.child(ZeebeNamespace, "subscription"); subscription != nil {
+				message.CorrelationKey = subscription.attr("correlationKey")
+			}
+		}
+		messages = append(messages, message)
+	}
+
+	return messages
+}
+
+// Signals returns the signals defined in the BPMN file, in document order
+func (d *Definitions) Signals() []Signal {
+	var signals []Signal
+	for _, e := range d.rootElements("signal") {
+		signals = append(signals, Signal{ID: e.id(), Name: e.attr("name")})
+	}
+
+	return signals
+}
+
+// Errors returns the errors defined in the BPMN file, in document order
+func (d *Definitions) Errors() []BPMNError {
+	var bpmnErrors []BPMNError
+	for _, e := range d.rootElements("error") {
+		bpmnErrors = append(bpmnErrors, BPMNError{ID: e.id(), Name: e.attr("name"), Code: e.attr("errorCode")})
+	}
+
+	return bpmnErrors
+}
+
+func (d *Definitions) rootElements(local string) []*element {
+	var elements []*element
+	for i := range d.root.Children {
+		if e := &d.root.Children[i]; e.XMLName.Space == BPMNNamespace && e.XMLName.Local == local {
+			elements = append(elements, e)
+		}
+	}
+
+	return elements
+}
@@ -0,0 +1,71 @@
+// Copyright © 2018 Camunda Services GmbH (info@camunda.com)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func parseOrderProcess(t *testing.T) *Definitions {
+	content, err := os.ReadFile("testdata/order.bpmn")
+	require.NoError(t, err)
+
+	definitions, err := ParseBPMN(content)
+	require.NoError(t, err)
+	require.NoError(t, Validate("order.bpmn", content))
+
+	return definitions
+}
+
+func TestJobTasks(t *testing.T) {
+	definitions := parseOrderProcess(t)
+
+	tasks := definitions.JobTasks()
+
+	require.Len(t, tasks, 4)
+	reserve := tasks[0]
+	require.Equal(t, "reserve", reserve.ID)
+	require.Equal(t, "order", reserve.ProcessID)
+	require.Equal(t, "reserve-items", reserve.TaskType)
+	require.Equal(t, "5", reserve.TaskRetries)
+	require.Equal(t, map[string]string{"warehouse": "main"}, reserve.TaskHeaders)
+
+	require.Equal(t, "ship", tasks[1].ID)
+	require.Empty(t, tasks[1].TaskRetries)
+	require.Nil(t, tasks[1].TaskHeaders)
+
+	require.Equal(t, "sendTask", tasks[2].Type)
+	require.Equal(t, "release", tasks[3].ID)
+	require.Equal(t, "cancellation", tasks[3].Scope)
+}
+
+func TestJobTypes(t *testing.T) {
+	definitions := parseOrderProcess(t)
+
+	require.Equal(t, []string{"release-items", "reserve-items", "ship-order"}, definitions.JobTypes())
+	require.Equal(t, []string{"release-items", "ship-order"}, definitions.MissingJobTypes("reserve-items", "other"))
+	require.Empty(t, definitions.MissingJobTypes("release-items", "reserve-items", "ship-order"))
+}
+
+func TestMessagesSignalsAndErrors(t *testing.T) {
+	definitions := parseOrderProcess(t)
+
+	require.Equal(t, []Message{{ID: "payment-received", Name: "payment-received", CorrelationKey: "=orderId"}}, definitions.Messages())
+	require.Equal(t, []Signal{{ID: "order-canceled", Name: "order-canceled"}}, definitions.Signals())
+	require.Equal(t, []BPMNError{{ID: "out-of-stock", Name: "Out of stock", Code: "OUT_OF_STOCK"}}, definitions.Errors())
+}
@@ -0,0 +1,87 @@
+// Copyright © 2018 Camunda Services GmbH (info@camunda.com)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package model parses BPMN, DMN and form resources and validates their structure locally, so that mistakes are
+// reported before the resources are deployed to the gateway. The checks are not exhaustive: a resource passing them
+// may still be rejected by the broker.
+package model
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// Problem describes a structural problem found in a resource
+type Problem struct {
+	// Resource is the name of the resource containing the problem
+	Resource string
+	// Element is the id of the element containing the problem, empty if the problem concerns the whole resource
+	Element string
+	// Message describes the problem
+	Message string
+}
+
+func (p Problem) String() string {
+	if p.Element == "" {
+		return fmt.Sprintf("%s: %s", p.Resource, p.Message)
+	}
+
+	return fmt.Sprintf("%s: element '%s': %s", p.Resource, p.Element, p.Message)
+}
+
+// ValidationError is returned when resources fail validation, and lists every problem found
+type ValidationError struct {
+	Problems []Problem
+}
+
+func (e *ValidationError) Error() string {
+	lines := make([]string, 0, len(e.Problems)+1)
+	if len(e.Problems) == 1 {
+		lines = append(lines, "resource validation failed with 1 problem:")
+	} else {
+		lines = append(lines, fmt.Sprintf("resource validation failed with %d problems:", len(e.Problems)))
+	}
+
+	for _, problem := range e.Problems {
+		lines = append(lines, "  "+problem.String())
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// Validate checks the structure of the resource, picking the BPMN, DMN or form checks based on the extension of the
+// resource name. Resources with another extension are left for the gateway to check. If problems are found, a
+// *ValidationError listing them is returned.
+func Validate(resourceName string, content []byte) error {
+	var problems []Problem
+	switch strings.ToLower(path.Ext(resourceName)) {
+	case ".bpmn":
+		problems = validateBPMN(content)
+	case ".dmn":
+		problems = validateDMN(content)
+	case ".form":
+		problems = validateForm(content)
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+
+	for i := range problems {
+		problems[i].Resource = resourceName
+	}
+
+	return &ValidationError{Problems: problems}
+}
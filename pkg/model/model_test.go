@@ -0,0 +1,96 @@
+// Copyright © 2018 Camunda Services GmbH (info@camunda.com)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateIgnoresUnknownResourceTypes(t *testing.T) {
+	require.NoError(t, Validate("notes.txt", []byte("not a model")))
+}
+
+func TestValidationErrorListsProblems(t *testing.T) {
+	err := &ValidationError{Problems: []Problem{
+		{Resource: "process.bpmn", Message: "contains no executable process"},
+		{Resource: "process.bpmn", Element: "task", Message: "service task has no zeebe:taskDefinition type"},
+	}}
+
+	require.Equal(t, "resource validation failed with 2 problems:\n"+
+		"  process.bpmn: contains no executable process\n"+
+		"  process.bpmn: element 'task': service task has no zeebe:taskDefinition type", err.Error())
+}
+
+func TestValidateValidDMN(t *testing.T) {
+	content, err := os.ReadFile("testdata/decision.dmn")
+	require.NoError(t, err)
+
+	require.NoError(t, Validate("decision.dmn", content))
+}
+
+func TestValidateDMNUnknownRequirements(t *testing.T) {
+	err := Validate("decision.dmn", []byte(`<definitions xmlns="https://www.omg.org/spec/DMN/20191111/MODEL/" id="drg">
+  <inputData id="input" />
+  <decision id="decision">
+    <informationRequirement id="requirement1">
+      <requiredInput href="#input" />
+    </informationRequirement>
+    <informationRequirement id="requirement2">
+      <requiredDecision href="#missing" />
+    </informationRequirement>
+  </decision>
+</definitions>`))
+
+	requireProblems(t, err, Problem{Resource: "decision.dmn", Element: "decision", Message: "requires unknown decision '#missing'"})
+}
+
+func TestValidateDMNWithoutDecision(t *testing.T) {
+	err := Validate("decision.dmn", []byte(`<definitions xmlns="https://www.omg.org/spec/DMN/20191111/MODEL/" id="drg" />`))
+
+	requireProblems(t, err, Problem{Resource: "decision.dmn", Message: "contains no decision"})
+}
+
+func TestValidateValidForm(t *testing.T) {
+	content, err := os.ReadFile("testdata/form.form")
+	require.NoError(t, err)
+
+	require.NoError(t, Validate("form.form", content))
+}
+
+func TestValidateFormProblems(t *testing.T) {
+	err := Validate("form.form", []byte(`{
+  "components": [
+    {"id": "field", "type": "textfield"},
+    {"id": "group", "type": "group", "components": [{"id": "field", "type": "number"}, {"id": "untyped"}]}
+  ]
+}`))
+
+	requireProblems(t, err,
+		Problem{Resource: "form.form", Message: "form has no id"},
+		Problem{Resource: "form.form", Element: "field", Message: "id is used by more than one form component"},
+		Problem{Resource: "form.form", Element: "untyped", Message: "form component has no type"},
+	)
+}
+
+func TestValidateInvalidForm(t *testing.T) {
+	err := Validate("form.form", []byte(`{"id": `))
+
+	var validationErr *ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	require.Len(t, validationErr.Problems, 1)
+}
@@ -0,0 +1,94 @@
+// Copyright © 2018 Camunda Services GmbH (info@camunda.com)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+)
+
+// ValidateStartElements checks that instances of the process can be started before the given elements, as requested
+// by the start instructions of a process instance creation. The elements must be flow nodes of the process, and must
+// not be boundary events, elements following an event-based gateway, or elements inside a multi-instance
+// sub-process. If the process ID is empty, the definitions must contain a single process. If problems are found, a
+// *ValidationError is returned, whose problems have the process ID as resource.
+func (d *Definitions) ValidateStartElements(processID string, elementIDs ...string) error {
+	process, err := d.process(processID)
+	if err != nil {
+		return err
+	}
+
+	nodes := make(map[string]*FlowNode, len(process.FlowNodes))
+	for _, node := range process.FlowNodes {
+		nodes[node.ID] = node
+	}
+
+	eventBasedTargets := make(map[string]bool)
+	for _, flow := range process.SequenceFlows {
+		if source, ok := nodes[flow.SourceRef]; ok && source.Type == "eventBasedGateway" {
+			eventBasedTargets[flow.TargetRef] = true
+		}
+	}
+
+	var problems []Problem
+	addProblem := func(elementID, format string, args ...interface{}) {
+		problems = append(problems, Problem{Resource: process.ID, Element: elementID, Message: fmt.Sprintf(format, args...)})
+	}
+
+	for _, elementID := range elementIDs {
+		node, ok := nodes[elementID]
+		switch {
+		case !ok:
+			addProblem(elementID, "no flow node with this id in process '%s'", process.ID)
+		case node.Type == "boundaryEvent":
+			addProblem(elementID, "cannot start before a boundary event")
+		case eventBasedTargets[elementID]:
+			addProblem(elementID, "cannot start before an element following an event-based gateway")
+		case insideMultiInstance(node, nodes):
+			addProblem(elementID, "cannot start before an element inside a multi-instance sub-process")
+		}
+	}
+
+	if len(problems) > 0 {
+		return &ValidationError{Problems: problems}
+	}
+	return nil
+}
+
+// process returns the process with the given ID, or the only process if the ID is empty
+func (d *Definitions) process(processID string) (*Process, error) {
+	if processID == "" {
+		if len(d.Processes) != 1 {
+			return nil, fmt.Errorf("expected a process id, the definitions contain %d processes", len(d.Processes))
+		}
+		return d.Processes[0], nil
+	}
+
+	for _, process := range d.Processes {
+		if process.ID == processID {
+			return process, nil
+		}
+	}
+	return nil, fmt.Errorf("no process with id '%s' in the definitions", processID)
+}
+
+// insideMultiInstance returns true if one of the enclosing sub-processes of the node is a multi-instance activity
+func insideMultiInstance(node *FlowNode, nodes map[string]*FlowNode) bool {
+	for scope, ok := nodes[node.Scope]; ok; scope, ok = nodes[scope.Scope] {
+		if scope.element.child(BPMNNamespace, "multiInstanceLoopCharacteristics") != nil {
+			return true
+		}
+	}
+	return false
+}
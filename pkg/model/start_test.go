@@ -0,0 +1,66 @@
+// Copyright © 2018 Camunda Services GmbH (info@camunda.com)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const startProcess = `
+    <bpmn:startEvent id="start" />
+    <bpmn:serviceTask id="task" />
+    <bpmn:boundaryEvent id="timeout" attachedToRef="task" />
+    <bpmn:eventBasedGateway id="gateway" />
+    <bpmn:intermediateCatchEvent id="wait" />
+    <bpmn:subProcess id="items">
+      <bpmn:multiInstanceLoopCharacteristics />
+      <bpmn:subProcess id="nested">
+        <bpmn:task id="item" />
+      </bpmn:subProcess>
+    </bpmn:subProcess>
+    <bpmn:subProcess id="review">
+      <bpmn:userTask id="approve" />
+    </bpmn:subProcess>
+    <bpmn:sequenceFlow id="flow1" sourceRef="start" targetRef="task" />
+    <bpmn:sequenceFlow id="flow2" sourceRef="task" targetRef="gateway" />
+    <bpmn:sequenceFlow id="flow3" sourceRef="gateway" targetRef="wait" />`
+
+func TestValidateStartElements(t *testing.T) {
+	definitions, err := ParseBPMN(bpmn(startProcess))
+	require.NoError(t, err)
+
+	require.NoError(t, definitions.ValidateStartElements("process", "task", "gateway", "items", "approve"))
+	require.NoError(t, definitions.ValidateStartElements("", "task"))
+
+	err = definitions.ValidateStartElements("process", "unknown", "timeout", "wait", "item")
+
+	requireProblems(t, err,
+		Problem{Resource: "process", Element: "unknown", Message: "no flow node with this id in process 'process'"},
+		Problem{Resource: "process", Element: "timeout", Message: "cannot start before a boundary event"},
+		Problem{Resource: "process", Element: "wait", Message: "cannot start before an element following an event-based gateway"},
+		Problem{Resource: "process", Element: "item", Message: "cannot start before an element inside a multi-instance sub-process"},
+	)
+}
+
+func TestValidateStartElementsOfUnknownProcess(t *testing.T) {
+	definitions, err := ParseBPMN(bpmn(startProcess))
+	require.NoError(t, err)
+
+	err = definitions.ValidateStartElements("other", "task")
+
+	require.EqualError(t, err, "no process with id 'other' in the definitions")
+}
@@ -0,0 +1,95 @@
+// Copyright © 2018 Camunda Services GmbH (info@camunda.com)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+)
+
+// element is a generic XML element, used to walk BPMN and DMN documents without modelling every element type
+type element struct {
+	XMLName  xml.Name
+	Attrs    []xml.Attr `xml:",any,attr"`
+	Children []element  `xml:",any"`
+	Text     string     `xml:",chardata"`
+}
+
+func parseXML(content []byte) (*element, error) {
+	var root element
+	decoder := xml.NewDecoder(bytes.NewReader(content))
+	if err := decoder.Decode(&root); err != nil {
+		return nil, fmt.Errorf("invalid XML: %w", err)
+	}
+
+	return &root, nil
+}
+
+// attr returns the value of the unqualified attribute with the given name, or an empty string
+func (e *element) attr(name string) string {
+	for _, attr := range e.Attrs {
+		if attr.Name.Space == "" && attr.Name.Local == name {
+			return attr.Value
+		}
+	}
+
+	return ""
+}
+
+func (e *element) id() string {
+	return e.attr("id")
+}
+
+// child returns the first child element with the given namespace and local name, or nil
+func (e *element) child(space, local string) *element {
+	for i := range e.Children {
+		if e.Children[i].XMLName.Space == space && e.Children[i].XMLName.Local == local {
+			return &e.Children[i]
+		}
+	}
+
+	return nil
+}
+
+// walk calls fn for the element and all its descendants, depth first
+func (e *element) walk(fn func(*element)) {
+	fn(e)
+	for i := range e.Children {
+		e.Children[i].walk(fn)
+	}
+}
+
+// duplicateIDs reports every id used by more than one element of the document
+func duplicateIDs(root *element) []Problem {
+	var problems []Problem
+	seen := make(map[string]bool)
+	reported := make(map[string]bool)
+
+	root.walk(func(e *element) {
+		id := e.id()
+		if id == "" {
+			return
+		}
+
+		if seen[id] && !reported[id] {
+			reported[id] = true
+			problems = append(problems, Problem{Element: id, Message: "id is used by more than one element"})
+		}
+		seen[id] = true
+	})
+
+	return problems
+}
@@ -0,0 +1,346 @@
+// Copyright © 2018 Camunda Services GmbH (info@camunda.com)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultBatchCompleterWindow       = 10 * time.Millisecond
+	DefaultBatchCompleterMaxBatchSize = 128
+	DefaultBatchCompleterConcurrency  = 8
+)
+
+// ErrBatchCompleterClosed is the result of the requests submitted to a closed BatchCompleter
+var ErrBatchCompleterClosed = errors.New("batch completer is closed")
+
+// ErrConflictingJobRequest is the result of a request repeating the operation of another request of the same batch on
+// the same job, but with different variables, retries, error code or message. Only the first request is sent.
+var ErrConflictingJobRequest = errors.New("conflicting request for the same job in the batch")
+
+// JobResult is the future result of a job completion, failure or error throw submitted to a BatchCompleter
+type JobResult struct {
+	done chan struct{}
+	err  error
+}
+
+func newJobResult() *JobResult {
+	return &JobResult{done: make(chan struct{})}
+}
+
+func (result *JobResult) resolve(err error) {
+	result.err = err
+	close(result.done)
+}
+
+// Done returns a channel which is closed once the request was sent to the gateway
+func (result *JobResult) Done() <-chan struct{} {
+	return result.done
+}
+
+// Err returns the error of the request, or nil if it succeeded or is not done yet
+func (result *JobResult) Err() error {
+	select {
+	case <-result.done:
+		return result.err
+	default:
+		return nil
+	}
+}
+
+// Wait blocks until the request was sent to the gateway and returns its error, or the error of ctx if it is done first
+func (result *JobResult) Wait(ctx context.Context) error {
+	select {
+	case <-result.done:
+		return result.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// jobOperation is what a request does with its job
+type jobOperation int
+
+const (
+	completeJobOperation jobOperation = iota
+	failJobOperation
+	throwErrorOperation
+)
+
+// batchRequestKey identifies the requests of a batch which are sent once
+type batchRequestKey struct {
+	jobKey    int64
+	operation jobOperation
+}
+
+type batchRequest struct {
+	key batchRequestKey
+	// payload identifies the content of the request, requests with the same key are only merged if it is equal
+	payload string
+	send    func(context.Context) error
+	result  *JobResult
+}
+
+// BatchCompleter sends the completions, failures and error throws of many goroutines with bounded concurrency.
+// Requests are collected over a small window, or until a batch is full, and are then sent with at most the configured
+// number of requests in flight.
+//
+// The gateway has no batch RPC, so each job is still completed, failed or has its error thrown by a request of its
+// own: batching bounds the concurrency towards the gateway, it does not reduce the number of requests. The only
+// requests saved are identical operations on the same job within a batch, e.g. two completions of the same job with the
+// same variables, which are sent once and share its result. Repeated operations with different variables, retries,
+// error code or message are not sent, their result being ErrConflictingJobRequest. Different operations on the same
+// job, e.g. a completion and a failure, are all sent, and the gateway rejects those coming after the first.
+type BatchCompleter struct {
+	client         JobClient
+	window         time.Duration
+	maxBatchSize   int
+	concurrency    int
+	requestTimeout time.Duration
+
+	mutex    sync.RWMutex
+	closed   bool
+	requests chan *batchRequest
+	inFlight chan struct{}
+	done     chan struct{}
+}
+
+type BatchCompleterOption func(*BatchCompleter)
+
+// WithBatchWindow sets how long requests are collected before a batch is sent. The window delays the requests, and
+// only saves the repeated ones, see BatchCompleter.
+func WithBatchWindow(window time.Duration) BatchCompleterOption {
+	return func(completer *BatchCompleter) {
+		if window > 0 {
+			completer.window = window
+		}
+	}
+}
+
+// WithMaxBatchSize sets the number of requests after which a batch is sent before its window elapsed
+func WithMaxBatchSize(size int) BatchCompleterOption {
+	return func(completer *BatchCompleter) {
+		if size > 0 {
+			completer.maxBatchSize = size
+		}
+	}
+}
+
+// WithBatchConcurrency sets the maximum number of requests sent to the gateway at the same time
+func WithBatchConcurrency(concurrency int) BatchCompleterOption {
+	return func(completer *BatchCompleter) {
+		if concurrency > 0 {
+			completer.concurrency = concurrency
+		}
+	}
+}
+
+// WithBatchRequestTimeout sets the timeout of each request sent to the gateway
+func WithBatchRequestTimeout(timeout time.Duration) BatchCompleterOption {
+	return func(completer *BatchCompleter) {
+		if timeout > 0 {
+			completer.requestTimeout = timeout
+		}
+	}
+}
+
+// NewBatchCompleter returns a BatchCompleter sending its requests with the commands of client, which is usually the
+// client passed to a job handler or the zeebe client itself. It must be closed to send the pending requests.
+func NewBatchCompleter(client JobClient, options ...BatchCompleterOption) *BatchCompleter {
+	completer := &BatchCompleter{
+		client:         client,
+		window:         DefaultBatchCompleterWindow,
+		maxBatchSize:   DefaultBatchCompleterMaxBatchSize,
+		concurrency:    DefaultBatchCompleterConcurrency,
+		requestTimeout: DefaultRequestTimeout,
+		done:           make(chan struct{}),
+	}
+	for _, option := range options {
+		option(completer)
+	}
+
+	completer.requests = make(chan *batchRequest, completer.maxBatchSize)
+	completer.inFlight = make(chan struct{}, completer.concurrency)
+
+	go completer.run()
+	return completer
+}
+
+// Complete submits the completion of the job with the given variables, which may be nil
+func (completer *BatchCompleter) Complete(jobKey int64, variables interface{}) *JobResult {
+	return completer.submit(batchRequestKey{jobKey, completeJobOperation}, variablesPayload(variables), func(ctx context.Context) error {
+		command := completer.client.NewCompleteJobCommand().JobKey(jobKey)
+		if variables == nil {
+			_, err := command.Send(ctx)
+			return err
+		}
+
+		dispatch, err := command.VariablesFromObject(variables)
+		if err != nil {
+			return err
+		}
+		_, err = dispatch.Send(ctx)
+		return err
+	})
+}
+
+// variablesPayload returns the JSON document of the variables, in which the keys of maps are sorted, to compare them
+func variablesPayload(variables interface{}) string {
+	if variables == nil {
+		return ""
+	}
+
+	b, err := json.Marshal(variables)
+	if err != nil {
+		// the command fails to encode them as well, the pointer keeps such requests apart
+		return fmt.Sprintf("%p", &variables)
+	}
+	return string(b)
+}
+
+// CompleteAsync submits the completion of the job and returns immediately. The callback, if not nil, is called with
+// the result of the request on another goroutine.
+func (completer *BatchCompleter) CompleteAsync(jobKey int64, variables interface{}, callback func(error)) {
+	result := completer.Complete(jobKey, variables)
+	if callback == nil {
+		return
+	}
+
+	go func() {
+		<-result.Done()
+		callback(result.Err())
+	}()
+}
+
+// Fail submits the failure of the job
+func (completer *BatchCompleter) Fail(jobKey int64, retries int32, errorMessage string) *JobResult {
+	payload := fmt.Sprintf("%d\x00%s", retries, errorMessage)
+	return completer.submit(batchRequestKey{jobKey, failJobOperation}, payload, func(ctx context.Context) error {
+		_, err := completer.client.NewFailJobCommand().JobKey(jobKey).Retries(retries).ErrorMessage(errorMessage).Send(ctx)
+		return err
+	})
+}
+
+// ThrowError submits the BPMN error with errorCode to be thrown for the job
+func (completer *BatchCompleter) ThrowError(jobKey int64, errorCode, errorMessage string) *JobResult {
+	payload := errorCode + "\x00" + errorMessage
+	return completer.submit(batchRequestKey{jobKey, throwErrorOperation}, payload, func(ctx context.Context) error {
+		_, err := completer.client.NewThrowErrorCommand().JobKey(jobKey).ErrorCode(errorCode).ErrorMessage(errorMessage).Send(ctx)
+		return err
+	})
+}
+
+// Close sends the pending requests and waits until all requests are done. Requests submitted afterwards fail with
+// ErrBatchCompleterClosed.
+func (completer *BatchCompleter) Close() {
+	completer.mutex.Lock()
+	if !completer.closed {
+		completer.closed = true
+		close(completer.requests)
+	}
+	completer.mutex.Unlock()
+
+	<-completer.done
+}
+
+func (completer *BatchCompleter) submit(key batchRequestKey, payload string, send func(context.Context) error) *JobResult {
+	result := newJobResult()
+
+	completer.mutex.RLock()
+	defer completer.mutex.RUnlock()
+
+	if completer.closed {
+		result.resolve(ErrBatchCompleterClosed)
+	} else {
+		completer.requests <- &batchRequest{key: key, payload: payload, send: send, result: result}
+	}
+	return result
+}
+
+func (completer *BatchCompleter) run() {
+	var (
+		batch    []*batchRequest
+		deadline <-chan time.Time
+		sending  sync.WaitGroup
+	)
+
+	for {
+		select {
+		case request, ok := <-completer.requests:
+			if !ok {
+				completer.send(batch, &sending)
+				sending.Wait()
+				close(completer.done)
+				return
+			}
+
+			if len(batch) == 0 {
+				deadline = time.After(completer.window)
+			}
+			batch = append(batch, request)
+
+			if len(batch) >= completer.maxBatchSize {
+				completer.send(batch, &sending)
+				batch, deadline = nil, nil
+			}
+		case <-deadline:
+			completer.send(batch, &sending)
+			batch, deadline = nil, nil
+		}
+	}
+}
+
+// send sends one request per job and operation of the batch, blocking while the maximum number of requests is in
+// flight. Repeated requests share the result of the first one if they are identical, or are rejected otherwise.
+func (completer *BatchCompleter) send(batch []*batchRequest, sending *sync.WaitGroup) {
+	first := make(map[batchRequestKey]*batchRequest, len(batch))
+	coalesced := make(map[batchRequestKey][]*JobResult, len(batch))
+	var requests []*batchRequest
+	for _, request := range batch {
+		sent, ok := first[request.key]
+		if !ok {
+			first[request.key] = request
+			requests = append(requests, request)
+		} else if sent.payload != request.payload {
+			request.result.resolve(ErrConflictingJobRequest)
+			continue
+		}
+		coalesced[request.key] = append(coalesced[request.key], request.result)
+	}
+
+	for _, request := range requests {
+		completer.inFlight <- struct{}{}
+		sending.Add(1)
+
+		go func(request *batchRequest, results []*JobResult) {
+			defer sending.Done()
+
+			ctx, cancel := context.WithTimeout(context.Background(), completer.requestTimeout)
+			err := request.send(ctx)
+			cancel()
+			<-completer.inFlight
+
+			for _, result := range results {
+				result.resolve(err)
+			}
+		}(request, coalesced[request.key])
+	}
+}
@@ -0,0 +1,197 @@
+// Copyright © 2018 Camunda Services GmbH (info@camunda.com)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/camunda-community-hub/zeebe-client-go/v8/internal/mock_pb"
+	"github.com/camunda-community-hub/zeebe-client-go/v8/pkg/pb"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func newBatchTestClient(t *testing.T) (*mock_pb.MockGatewayClient, JobClient) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	gateway := mock_pb.NewMockGatewayClient(ctrl)
+	return gateway, configuredJobClient{gatewayClient: gateway, shouldRetry: noRetry}
+}
+
+func TestBatchCompleter_Complete(t *testing.T) {
+	gateway, client := newBatchTestClient(t)
+	gateway.EXPECT().CompleteJob(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, request *pb.CompleteJobRequest, _ ...interface{}) (*pb.CompleteJobResponse, error) {
+			assert.Equal(t, int64(1), request.JobKey)
+			assert.JSONEq(t, `{"paid": true}`, request.Variables)
+			return &pb.CompleteJobResponse{}, nil
+		})
+
+	completer := NewBatchCompleter(client)
+	defer completer.Close()
+
+	result := completer.Complete(1, map[string]interface{}{"paid": true})
+
+	require.NoError(t, result.Wait(context.Background()))
+}
+
+func TestBatchCompleter_FailAndThrowError(t *testing.T) {
+	gateway, client := newBatchTestClient(t)
+	gateway.EXPECT().FailJob(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, request *pb.FailJobRequest, _ ...interface{}) (*pb.FailJobResponse, error) {
+			assert.Equal(t, int64(1), request.JobKey)
+			assert.Equal(t, int32(2), request.Retries)
+			assert.Equal(t, "boom", request.ErrorMessage)
+			return nil, errors.New("unavailable")
+		})
+	gateway.EXPECT().ThrowError(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, request *pb.ThrowErrorRequest, _ ...interface{}) (*pb.ThrowErrorResponse, error) {
+			assert.Equal(t, int64(2), request.JobKey)
+			assert.Equal(t, "OUT_OF_STOCK", request.ErrorCode)
+			return &pb.ThrowErrorResponse{}, nil
+		})
+
+	completer := NewBatchCompleter(client)
+	defer completer.Close()
+
+	failed := completer.Fail(1, 2, "boom")
+	thrown := completer.ThrowError(2, "OUT_OF_STOCK", "no stock")
+
+	assert.EqualError(t, failed.Wait(context.Background()), "unavailable")
+	assert.NoError(t, thrown.Wait(context.Background()))
+}
+
+func TestBatchCompleter_CoalescesRequestsForTheSameJob(t *testing.T) {
+	gateway, client := newBatchTestClient(t)
+	gateway.EXPECT().CompleteJob(gomock.Any(), gomock.Any()).Return(&pb.CompleteJobResponse{}, nil).Times(1)
+
+	completer := NewBatchCompleter(client, WithBatchWindow(time.Hour), WithMaxBatchSize(2))
+	defer completer.Close()
+
+	first := completer.Complete(1, nil)
+	second := completer.Complete(1, nil)
+
+	assert.NoError(t, first.Wait(context.Background()))
+	assert.NoError(t, second.Wait(context.Background()))
+}
+
+func TestBatchCompleter_RejectsConflictingRequestsForTheSameJob(t *testing.T) {
+	gateway, client := newBatchTestClient(t)
+	gateway.EXPECT().CompleteJob(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, request *pb.CompleteJobRequest, _ ...interface{}) (*pb.CompleteJobResponse, error) {
+			assert.JSONEq(t, `{"paid": true}`, request.Variables)
+			return &pb.CompleteJobResponse{}, nil
+		}).Times(1)
+
+	completer := NewBatchCompleter(client, WithBatchWindow(time.Hour), WithMaxBatchSize(3))
+	defer completer.Close()
+
+	first := completer.Complete(1, map[string]interface{}{"paid": true})
+	identical := completer.Complete(1, map[string]interface{}{"paid": true})
+	conflicting := completer.Complete(1, map[string]interface{}{"paid": false})
+
+	assert.NoError(t, first.Wait(context.Background()))
+	assert.NoError(t, identical.Wait(context.Background()))
+	assert.ErrorIs(t, conflicting.Wait(context.Background()), ErrConflictingJobRequest)
+}
+
+func TestBatchCompleter_SendsDifferentOperationsForTheSameJob(t *testing.T) {
+	gateway, client := newBatchTestClient(t)
+	gateway.EXPECT().CompleteJob(gomock.Any(), gomock.Any()).Return(&pb.CompleteJobResponse{}, nil).Times(1)
+	gateway.EXPECT().FailJob(gomock.Any(), gomock.Any()).Return(nil, status.Error(codes.NotFound, "job not found")).Times(1)
+
+	completer := NewBatchCompleter(client, WithBatchWindow(time.Hour), WithMaxBatchSize(2))
+	defer completer.Close()
+
+	completion := completer.Complete(1, nil)
+	failure := completer.Fail(1, 0, "failed")
+
+	assert.NoError(t, completion.Wait(context.Background()))
+	assert.Error(t, failure.Wait(context.Background()))
+}
+
+func TestBatchCompleter_BoundsConcurrency(t *testing.T) {
+	var active, maxActive int32
+	gateway, client := newBatchTestClient(t)
+	gateway.EXPECT().CompleteJob(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(context.Context, *pb.CompleteJobRequest, ...interface{}) (*pb.CompleteJobResponse, error) {
+			current := atomic.AddInt32(&active, 1)
+			for {
+				previous := atomic.LoadInt32(&maxActive)
+				if current <= previous || atomic.CompareAndSwapInt32(&maxActive, previous, current) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			atomic.AddInt32(&active, -1)
+			return &pb.CompleteJobResponse{}, nil
+		}).Times(10)
+
+	completer := NewBatchCompleter(client, WithBatchConcurrency(2))
+
+	var results []*JobResult
+	for i := 0; i < 10; i++ {
+		results = append(results, completer.Complete(int64(i), nil))
+	}
+	completer.Close()
+
+	for _, result := range results {
+		assert.NoError(t, result.Err())
+	}
+	assert.LessOrEqual(t, atomic.LoadInt32(&maxActive), int32(2))
+}
+
+func TestBatchCompleter_CompleteAsync(t *testing.T) {
+	gateway, client := newBatchTestClient(t)
+	gateway.EXPECT().CompleteJob(gomock.Any(), gomock.Any()).Return(&pb.CompleteJobResponse{}, nil)
+
+	completer := NewBatchCompleter(client)
+	defer completer.Close()
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	completer.CompleteAsync(1, nil, func(err error) {
+		assert.NoError(t, err)
+		wg.Done()
+	})
+	wg.Wait()
+}
+
+func TestBatchCompleter_CloseSendsPendingRequests(t *testing.T) {
+	gateway, client := newBatchTestClient(t)
+	gateway.EXPECT().CompleteJob(gomock.Any(), gomock.Any()).Return(&pb.CompleteJobResponse{}, nil)
+
+	completer := NewBatchCompleter(client, WithBatchWindow(time.Hour))
+
+	pending := completer.Complete(1, nil)
+	completer.Close()
+
+	select {
+	case <-pending.Done():
+		assert.NoError(t, pending.Err())
+	default:
+		t.Error("expected pending request to be sent on close")
+	}
+	assert.ErrorIs(t, completer.Complete(2, nil).Err(), ErrBatchCompleterClosed)
+}
@@ -17,10 +17,13 @@ package worker
 
 import (
 	"context"
+	"log"
 	"sync"
 	"time"
 
 	"github.com/camunda-community-hub/zeebe-client-go/v8/pkg/commands"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type jobStreamer struct {
@@ -56,6 +59,13 @@ func (streamer *jobStreamer) stream(closeWait *sync.WaitGroup) {
 				return
 			}
 
+			// the poller keeps activating jobs, so the worker simply continues without streaming
+			if status.Code(err) == codes.Unimplemented {
+				log.Printf("Job streaming is not supported by the gateway, falling back to polling: %v\n", err)
+				streamer.close()
+				return
+			}
+
 			if err != nil {
 				prevDelay := retryDelay
 				retryDelay = streamer.backoffSupplier.SupplyRetryDelay(prevDelay)
@@ -26,6 +26,8 @@ import (
 	"github.com/camunda-community-hub/zeebe-client-go/v8/pkg/commands"
 	"github.com/camunda-community-hub/zeebe-client-go/v8/pkg/entities"
 	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type JobStreamerSuite struct {
@@ -160,6 +162,31 @@ func (s *JobStreamerSuite) TestShouldRecreateStreamOnErrorWithBackoff() {
 	}
 }
 
+func (s *JobStreamerSuite) TestShouldStopStreamingIfUnsupportedByGateway() {
+	// given
+	state := newTestState()
+	defer state.close(s)
+	state.command.err = status.Error(codes.Unimplemented, "unknown method StreamActivatedJobs")
+	finished := make(chan bool)
+
+	// when - should return on its own, as the poller keeps activating jobs
+	go func() {
+		state.streamer.stream(state.waitGroup)
+		finished <- true
+		close(finished)
+	}()
+
+	// then
+	select {
+	case <-finished:
+		s.EqualValues(1, state.command.sendCount)
+		s.EqualValues(0, state.backoff.calledCount)
+		s.True(state.streamer.isClosed())
+	case <-time.After(10 * time.Second):
+		s.FailNow("Timed out waiting for streamer to stop")
+	}
+}
+
 type testState struct {
 	command   *mockStreamJobsCommand
 	backoff   *mockBackoffSupplier
@@ -16,10 +16,15 @@
 package worker
 
 import (
+	"context"
+	"fmt"
+	"log"
 	"sync"
 
 	"github.com/camunda-community-hub/zeebe-client-go/v8/pkg/commands"
 	"github.com/camunda-community-hub/zeebe-client-go/v8/pkg/entities"
+	"github.com/camunda-community-hub/zeebe-client-go/v8/pkg/pb"
+	"google.golang.org/protobuf/proto"
 )
 
 type JobClient interface {
@@ -30,6 +35,85 @@ type JobClient interface {
 
 type JobHandler func(client JobClient, job entities.Job)
 
+// JobVariablesReader reads the variables of jobs with the serializer and claim check it is configured with. It is
+// implemented by the JobClient passed to handlers by workers, and by the client returned by zbc.NewClient.
+type JobVariablesReader interface {
+	NewJobVariables(job entities.Job) *entities.JobVariables
+}
+
+// JobVariables returns the variables of the job, read with the serializer and claim check of the client if it is a
+// JobVariablesReader, like the JobClient passed to a JobHandler, or with the defaults otherwise.
+//
+//	func handle(client worker.JobClient, job entities.Job) {
+//		orderID, err := entities.GetVariable[string](worker.JobVariables(client, job), "orderId")
+//		...
+//	}
+func JobVariables(client JobClient, job entities.Job) *entities.JobVariables {
+	if reader, ok := client.(JobVariablesReader); ok {
+		return reader.NewJobVariables(job)
+	}
+
+	return entities.NewJobVariables(job, nil, nil)
+}
+
+// claimCheckResolvingHandler passes the jobs to the handler with the variables offloaded to the claim check store
+// resolved, so that handlers read them like any other variable. Jobs whose variables cannot be resolved are failed,
+// decrementing their retries.
+func claimCheckResolvingHandler(handler JobHandler, claimCheck *entities.ClaimCheck) JobHandler {
+	return func(client JobClient, job entities.Job) {
+		ctx, cancel := context.WithTimeout(context.Background(), DefaultRequestTimeout)
+		defer cancel()
+
+		variables, err := claimCheck.ResolveVariables(ctx, job.GetVariables())
+		if err != nil {
+			message := fmt.Sprintf("failed to resolve claim checks: %s", err)
+			_, err = client.NewFailJobCommand().JobKey(job.Key).Retries(job.Retries - 1).ErrorMessage(message).Send(ctx)
+			if err != nil {
+				log.Printf("Failed to fail job %d with unresolved claim checks: %v\n", job.Key, err)
+			}
+			return
+		}
+
+		if variables != job.GetVariables() {
+			// the activated job may be shared, e.g. with a job stream consumer, so it is copied instead of changed
+			resolved := proto.Clone(job.ActivatedJob).(*pb.ActivatedJob)
+			resolved.Variables = variables
+			job = entities.Job{ActivatedJob: resolved}
+		}
+
+		handler(client, job)
+	}
+}
+
+// configuredJobClient is the JobClient of workers with their own serializer, claim check, variables size limit or
+// output schema, creating commands configured accordingly
+type configuredJobClient struct {
+	gatewayClient pb.GatewayClient
+	shouldRetry   func(context.Context, error) bool
+	options       []commands.CommandOption
+	serializer    entities.Serializer
+	claimCheck    *entities.ClaimCheck
+	// completeOptions are added to options for the commands completing jobs
+	completeOptions []commands.CommandOption
+}
+
+func (c configuredJobClient) NewCompleteJobCommand() commands.CompleteJobCommandStep1 {
+	options := append(append([]commands.CommandOption{}, c.options...), c.completeOptions...)
+	return commands.NewCompleteJobCommand(c.gatewayClient, c.shouldRetry, options...)
+}
+
+func (c configuredJobClient) NewJobVariables(job entities.Job) *entities.JobVariables {
+	return entities.NewJobVariables(job, c.serializer, c.claimCheck)
+}
+
+func (c configuredJobClient) NewFailJobCommand() commands.FailJobCommandStep1 {
+	return commands.NewFailJobCommand(c.gatewayClient, c.shouldRetry, c.options...)
+}
+
+func (c configuredJobClient) NewThrowErrorCommand() commands.ThrowErrorCommandStep1 {
+	return commands.NewThrowErrorCommand(c.gatewayClient, c.shouldRetry, c.options...)
+}
+
 type JobWorker interface {
 	// Initiate graceful shutdown and awaits termination
 	Close()
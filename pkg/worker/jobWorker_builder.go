@@ -55,6 +55,13 @@ type JobWorkerBuilder struct {
 	backoffSupplier      BackoffSupplier
 	streamEnabled        bool
 	streamRequestTimeout time.Duration
+	serializer           entities.Serializer
+	claimCheck           *entities.ClaimCheck
+	maxVariablesSize     int
+
+	inputSchema           *VariablesSchema
+	outputSchema          *VariablesSchema
+	invalidInputErrorCode string
 }
 
 type JobWorkerBuilderStep1 interface {
@@ -96,6 +103,27 @@ type JobWorkerBuilderStep3 interface {
 	StreamEnabled(bool) JobWorkerBuilderStep3
 	// StreamRequestTimeout If streaming is enabled, this sets the timeout on the underlying job stream. It's useful to set a few hours to load-balance your streams over time.
 	StreamRequestTimeout(time.Duration) JobWorkerBuilderStep3
+	// Serializer Set the serializer encoding the variables of the commands created through the JobClient passed to the
+	// handler, and decoding the variables of activated jobs read through JobVariables. Job.GetVariablesAs and
+	// Job.GetVariablesAsMap bypass it and always use encoding/json. Defaults to the serializer of the client.
+	Serializer(entities.Serializer) JobWorkerBuilderStep3
+	// ClaimCheck Set the claim check offloading large variables of the commands created through the JobClient passed to
+	// the handler, and resolving the offloaded variables of activated jobs before they are passed to the handler. Jobs
+	// whose offloaded variables cannot be fetched are failed. Defaults to the claim check of the client.
+	ClaimCheck(*entities.ClaimCheck) JobWorkerBuilderStep3
+	// MaxVariablesSize Set the size limit in bytes of the variables of the commands created through the JobClient passed
+	// to the handler. Defaults to the limit of the client.
+	MaxVariablesSize(int) JobWorkerBuilderStep3
+	// InputSchema Set the schema the variables of activated jobs must match. Jobs with invalid variables are not passed to
+	// the handler, but failed without retries, raising an incident, or rejected with the BPMN error set with
+	// InvalidInputErrorCode.
+	InputSchema(*VariablesSchema) JobWorkerBuilderStep3
+	// InvalidInputErrorCode Set the code of the BPMN error thrown for jobs whose variables do not match the input schema,
+	// instead of failing them
+	InvalidInputErrorCode(string) JobWorkerBuilderStep3
+	// OutputSchema Set the schema the variables completing jobs must match. The commands created through the JobClient
+	// passed to the handler return an *InvalidVariablesError for invalid variables, before completing the job.
+	OutputSchema(*VariablesSchema) JobWorkerBuilderStep3
 	// Open the job worker and start polling and handling jobs
 	Open() JobWorker
 }
@@ -188,6 +216,45 @@ func (builder *JobWorkerBuilder) StreamRequestTimeout(requestTimeout time.Durati
 	return builder
 }
 
+func (builder *JobWorkerBuilder) Serializer(serializer entities.Serializer) JobWorkerBuilderStep3 {
+	builder.serializer = serializer
+	return builder
+}
+
+func (builder *JobWorkerBuilder) ClaimCheck(claimCheck *entities.ClaimCheck) JobWorkerBuilderStep3 {
+	builder.claimCheck = claimCheck
+	return builder
+}
+
+func (builder *JobWorkerBuilder) MaxVariablesSize(maxSize int) JobWorkerBuilderStep3 {
+	builder.maxVariablesSize = maxSize
+	return builder
+}
+
+func (builder *JobWorkerBuilder) InputSchema(schema *VariablesSchema) JobWorkerBuilderStep3 {
+	builder.inputSchema = schema
+	return builder
+}
+
+func (builder *JobWorkerBuilder) InvalidInputErrorCode(errorCode string) JobWorkerBuilderStep3 {
+	builder.invalidInputErrorCode = errorCode
+	return builder
+}
+
+func (builder *JobWorkerBuilder) OutputSchema(schema *VariablesSchema) JobWorkerBuilderStep3 {
+	builder.outputSchema = schema
+	return builder
+}
+
+// commandOptions returns the options of the commands created by the worker
+func (builder *JobWorkerBuilder) commandOptions() []commands.CommandOption {
+	return []commands.CommandOption{
+		commands.WithSerializer(builder.serializer),
+		commands.WithClaimCheck(builder.claimCheck),
+		commands.WithMaxVariablesSize(builder.maxVariablesSize),
+	}
+}
+
 func (builder *JobWorkerBuilder) Open() JobWorker {
 	jobQueue := make(chan entities.Job, builder.maxJobsActive)
 	workerFinished := make(chan bool, builder.maxJobsActive)
@@ -221,11 +288,34 @@ func (builder *JobWorkerBuilder) Open() JobWorker {
 		closeSignal:    closeDispatcher,
 	}
 
-	go dispatcher.run(builder.jobClient, builder.handler, builder.concurrency, &closeWait)
+	jobClient := builder.jobClient
+	if builder.serializer != nil || builder.claimCheck != nil || builder.maxVariablesSize > 0 || builder.outputSchema != nil {
+		configured := configuredJobClient{
+			gatewayClient: builder.gatewayClient,
+			shouldRetry:   builder.shouldRetry,
+			options:       builder.commandOptions(),
+			serializer:    builder.serializer,
+			claimCheck:    builder.claimCheck,
+		}
+		if builder.outputSchema != nil {
+			configured.completeOptions = []commands.CommandOption{commands.WithVariablesValidator(builder.outputSchema.Validate)}
+		}
+		jobClient = configured
+	}
+
+	handler := builder.handler
+	if builder.inputSchema != nil {
+		handler = inputValidatingHandler(handler, builder.inputSchema, builder.invalidInputErrorCode)
+	}
+	if builder.claimCheck != nil {
+		handler = claimCheckResolvingHandler(handler, builder.claimCheck)
+	}
+
+	go dispatcher.run(jobClient, handler, builder.concurrency, &closeWait)
 	go poller.poll(&closeWait)
 
 	if builder.streamEnabled {
-		streamRequest := commands.NewStreamJobsCommand(builder.gatewayClient, builder.shouldRetry).
+		streamRequest := commands.NewStreamJobsCommand(builder.gatewayClient, builder.shouldRetry, builder.commandOptions()...).
 			JobType(builder.request.Type).
 			Consumer(jobQueue).
 			Timeout(time.Duration(builder.request.Timeout) * time.Millisecond).
@@ -131,3 +131,39 @@ func TestJobWorkerBuilder_StreamRequestTimeout(t *testing.T) {
 	builder.StreamRequestTimeout(requestTimeout)
 	assert.Equal(t, requestTimeout, builder.streamRequestTimeout)
 }
+
+func TestJobWorkerBuilder_Serializer(t *testing.T) {
+	builder := JobWorkerBuilder{}
+	builder.Serializer(entities.JSONSerializer)
+	assert.Equal(t, entities.JSONSerializer, builder.serializer)
+}
+
+func TestJobWorkerBuilder_ClaimCheck(t *testing.T) {
+	claimCheck := &entities.ClaimCheck{Threshold: 1024}
+	builder := JobWorkerBuilder{}
+	builder.ClaimCheck(claimCheck)
+	assert.Equal(t, claimCheck, builder.claimCheck)
+}
+
+func TestJobWorkerBuilder_MaxVariablesSize(t *testing.T) {
+	builder := JobWorkerBuilder{}
+	builder.MaxVariablesSize(1024)
+	assert.Equal(t, 1024, builder.maxVariablesSize)
+}
+
+func TestJobWorkerBuilder_InputSchema(t *testing.T) {
+	schema, err := CompileVariablesSchema(`{"type": "object"}`)
+	assert.NoError(t, err)
+	builder := JobWorkerBuilder{}
+	builder.InputSchema(schema).InvalidInputErrorCode("INVALID_INPUT")
+	assert.Equal(t, schema, builder.inputSchema)
+	assert.Equal(t, "INVALID_INPUT", builder.invalidInputErrorCode)
+}
+
+func TestJobWorkerBuilder_OutputSchema(t *testing.T) {
+	schema, err := CompileVariablesSchema(`{"type": "object"}`)
+	assert.NoError(t, err)
+	builder := JobWorkerBuilder{}
+	builder.OutputSchema(schema)
+	assert.Equal(t, schema, builder.outputSchema)
+}
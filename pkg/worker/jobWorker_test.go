@@ -19,6 +19,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"strings"
 	"testing"
 	"time"
 
@@ -340,3 +341,83 @@ func TestJobWorkerClose(t *testing.T) {
 		break
 	}
 }
+
+// upperCaseSerializer decodes strings in upper case, to tell it apart from encoding/json
+type upperCaseSerializer struct{}
+
+func (upperCaseSerializer) Marshal(v interface{}) ([]byte, error) {
+	return entities.JSONSerializer.Marshal(v)
+}
+
+func (upperCaseSerializer) Unmarshal(data []byte, v interface{}) error {
+	if s, ok := v.(*string); ok {
+		data = []byte(strings.ToUpper(string(data)))
+		return entities.JSONSerializer.Unmarshal(data, s)
+	}
+
+	return entities.JSONSerializer.Unmarshal(data, v)
+}
+
+func TestJobVariables(t *testing.T) {
+	job := entities.Job{ActivatedJob: &pb.ActivatedJob{Variables: `{"orderId": "order-1"}`}}
+
+	configured := configuredJobClient{serializer: upperCaseSerializer{}}
+	orderID, err := entities.GetVariable[string](JobVariables(configured, job), "orderId")
+	assert.NoError(t, err)
+	assert.Equal(t, "ORDER-1", orderID)
+
+	var client JobClient = struct{ JobClient }{}
+	orderID, err = entities.GetVariable[string](JobVariables(client, job), "orderId")
+	assert.NoError(t, err)
+	assert.Equal(t, "order-1", orderID)
+}
+
+type mapBlobStore map[string]string
+
+func (s mapBlobStore) Put(context.Context, []byte) (string, error) {
+	return "", fmt.Errorf("not implemented")
+}
+
+func (s mapBlobStore) Get(_ context.Context, reference string) ([]byte, error) {
+	value, ok := s[reference]
+	if !ok {
+		return nil, fmt.Errorf("no blob %s", reference)
+	}
+	return []byte(value), nil
+}
+
+func TestClaimCheckResolvingHandlerResolvesVariables(t *testing.T) {
+	claimCheck := &entities.ClaimCheck{Store: mapBlobStore{"blob-0": `{"sku":"a"}`}}
+	activated := &pb.ActivatedJob{Key: 1, Variables: `{"order":{"$claimCheck":"blob-0"},"small":1}`}
+
+	var variables map[string]interface{}
+	handler := claimCheckResolvingHandler(func(_ JobClient, job entities.Job) {
+		assert.NoError(t, job.GetVariablesAs(&variables))
+	}, claimCheck)
+
+	handler(nil, entities.Job{ActivatedJob: activated})
+
+	assert.Equal(t, map[string]interface{}{"order": map[string]interface{}{"sku": "a"}, "small": float64(1)}, variables)
+	assert.Equal(t, `{"order":{"$claimCheck":"blob-0"},"small":1}`, activated.Variables)
+}
+
+func TestClaimCheckResolvingHandlerFailsUnresolvedJobs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gateway := mock_pb.NewMockGatewayClient(ctrl)
+	gateway.EXPECT().FailJob(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, request *pb.FailJobRequest, _ ...interface{}) (*pb.FailJobResponse, error) {
+			assert.Equal(t, int64(1), request.JobKey)
+			assert.Equal(t, int32(2), request.Retries)
+			assert.Contains(t, request.ErrorMessage, "order")
+			return &pb.FailJobResponse{}, nil
+		})
+
+	handler := claimCheckResolvingHandler(func(JobClient, entities.Job) {
+		t.Error("handler called for unresolved job")
+	}, &entities.ClaimCheck{Store: mapBlobStore{}})
+
+	job := entities.Job{ActivatedJob: &pb.ActivatedJob{Key: 1, Retries: 3, Variables: `{"order":{"$claimCheck":"missing"}}`}}
+	handler(configuredJobClient{gatewayClient: gateway, shouldRetry: noRetry}, job)
+}
@@ -0,0 +1,117 @@
+// Copyright © 2018 Camunda Services GmbH (info@camunda.com)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/camunda-community-hub/zeebe-client-go/v8/pkg/entities"
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+// VariablesSchema is a compiled JSON Schema which the variables of jobs, or of their completion, must match. See
+// JobWorkerBuilderStep3.InputSchema and JobWorkerBuilderStep3.OutputSchema.
+type VariablesSchema struct {
+	schema *jsonschema.Schema
+}
+
+// InvalidVariablesError is returned when variables do not match a VariablesSchema, listing every mismatch
+type InvalidVariablesError struct {
+	// Problems describe the mismatches, prefixed with the JSON pointer of the offending value
+	Problems []string
+}
+
+func (e *InvalidVariablesError) Error() string {
+	return "variables do not match the schema: " + strings.Join(e.Problems, "; ")
+}
+
+// CompileVariablesSchema compiles a JSON Schema, given as a JSON document. Drafts 4 to 2020-12 are supported, the
+// $schema keyword selecting the draft, and the latest one being used if it is missing.
+func CompileVariablesSchema(schema string) (*VariablesSchema, error) {
+	compiled, err := jsonschema.CompileString("variables.schema.json", schema)
+	if err != nil {
+		return nil, err
+	}
+
+	return &VariablesSchema{schema: compiled}, nil
+}
+
+// Validate checks that the variables, a JSON document, match the schema. Returns an *InvalidVariablesError otherwise.
+func (s *VariablesSchema) Validate(variables string) error {
+	if strings.TrimSpace(variables) == "" {
+		variables = "{}"
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader([]byte(variables)))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return &InvalidVariablesError{Problems: []string{fmt.Sprintf("invalid JSON: %s", err)}}
+	}
+
+	err := s.schema.Validate(value)
+	if validationErr, ok := err.(*jsonschema.ValidationError); ok {
+		return &InvalidVariablesError{Problems: schemaProblems(validationErr)}
+	}
+
+	return err
+}
+
+// schemaProblems flattens the causes of the validation error to their leaves, which describe the actual mismatches
+func schemaProblems(err *jsonschema.ValidationError) []string {
+	if len(err.Causes) == 0 {
+		return []string{fmt.Sprintf("'%s': %s", err.InstanceLocation, err.Message)}
+	}
+
+	var problems []string
+	for _, cause := range err.Causes {
+		problems = append(problems, schemaProblems(cause)...)
+	}
+
+	return problems
+}
+
+// inputValidatingHandler rejects the jobs whose variables do not match the input schema, before the handler runs.
+// Rejected jobs are failed without retries, raising an incident, or get the BPMN error with errorCode thrown if it is
+// not empty.
+func inputValidatingHandler(handler JobHandler, schema *VariablesSchema, errorCode string) JobHandler {
+	return func(client JobClient, job entities.Job) {
+		err := schema.Validate(job.GetVariables())
+		if err == nil {
+			handler(client, job)
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), DefaultRequestTimeout)
+		defer cancel()
+
+		message := fmt.Sprintf("invalid input: %s", err)
+		if errorCode != "" {
+			_, err = client.NewThrowErrorCommand().JobKey(job.Key).ErrorCode(errorCode).ErrorMessage(message).Send(ctx)
+		} else {
+			_, err = client.NewFailJobCommand().JobKey(job.Key).Retries(0).ErrorMessage(message).Send(ctx)
+		}
+
+		if err != nil {
+			log.Printf("Failed to reject job %d with invalid input: %v\n", job.Key, err)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+// Copyright © 2018 Camunda Services GmbH (info@camunda.com)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/camunda-community-hub/zeebe-client-go/v8/internal/mock_pb"
+	"github.com/camunda-community-hub/zeebe-client-go/v8/pkg/commands"
+	"github.com/camunda-community-hub/zeebe-client-go/v8/pkg/entities"
+	"github.com/camunda-community-hub/zeebe-client-go/v8/pkg/pb"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const orderSchema = `{
+  "type": "object",
+  "required": ["orderId"],
+  "properties": {
+    "orderId": {"type": "string"},
+    "amount": {"type": "number", "minimum": 0}
+  }
+}`
+
+func noRetry(context.Context, error) bool {
+	return false
+}
+
+func TestVariablesSchema_Validate(t *testing.T) {
+	schema, err := CompileVariablesSchema(orderSchema)
+	require.NoError(t, err)
+
+	assert.NoError(t, schema.Validate(`{"orderId": "order-1", "amount": 12.5}`))
+
+	err = schema.Validate(`{"amount": -1}`)
+	var invalidErr *InvalidVariablesError
+	require.ErrorAs(t, err, &invalidErr)
+	assert.Len(t, invalidErr.Problems, 2)
+	assert.Contains(t, err.Error(), "orderId")
+	assert.Contains(t, err.Error(), "/amount")
+
+	assert.Error(t, schema.Validate(`not json`))
+}
+
+func TestCompileVariablesSchemaRejectsInvalidSchema(t *testing.T) {
+	_, err := CompileVariablesSchema(`{"type": 12}`)
+	assert.Error(t, err)
+}
+
+func TestInputValidatingHandlerPassesValidJobs(t *testing.T) {
+	schema, err := CompileVariablesSchema(orderSchema)
+	require.NoError(t, err)
+
+	handled := false
+	handler := inputValidatingHandler(func(JobClient, entities.Job) { handled = true }, schema, "")
+
+	handler(nil, entities.Job{ActivatedJob: &pb.ActivatedJob{Key: 1, Variables: `{"orderId": "order-1"}`}})
+
+	assert.True(t, handled)
+}
+
+func TestInputValidatingHandlerFailsInvalidJobs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gateway := mock_pb.NewMockGatewayClient(ctrl)
+	gateway.EXPECT().FailJob(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, request *pb.FailJobRequest, _ ...interface{}) (*pb.FailJobResponse, error) {
+			assert.Equal(t, int64(1), request.JobKey)
+			assert.Equal(t, int32(0), request.Retries)
+			assert.Contains(t, request.ErrorMessage, "invalid input")
+			return &pb.FailJobResponse{}, nil
+		})
+
+	schema, err := CompileVariablesSchema(orderSchema)
+	require.NoError(t, err)
+
+	handler := inputValidatingHandler(func(JobClient, entities.Job) { t.Error("handler called for invalid job") }, schema, "")
+
+	handler(configuredJobClient{gatewayClient: gateway, shouldRetry: noRetry}, entities.Job{ActivatedJob: &pb.ActivatedJob{Key: 1, Variables: `{}`}})
+}
+
+func TestInputValidatingHandlerThrowsErrorForInvalidJobs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gateway := mock_pb.NewMockGatewayClient(ctrl)
+	gateway.EXPECT().ThrowError(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, request *pb.ThrowErrorRequest, _ ...interface{}) (*pb.ThrowErrorResponse, error) {
+			assert.Equal(t, int64(1), request.JobKey)
+			assert.Equal(t, "INVALID_ORDER", request.ErrorCode)
+			return &pb.ThrowErrorResponse{}, nil
+		})
+
+	schema, err := CompileVariablesSchema(orderSchema)
+	require.NoError(t, err)
+
+	handler := inputValidatingHandler(func(JobClient, entities.Job) { t.Error("handler called for invalid job") }, schema, "INVALID_ORDER")
+
+	handler(configuredJobClient{gatewayClient: gateway, shouldRetry: noRetry}, entities.Job{ActivatedJob: &pb.ActivatedJob{Key: 1, Variables: `{"orderId": 1}`}})
+}
+
+func TestOutputSchemaIsCheckedBeforeCompletingJobs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// the gateway must not be called for invalid variables
+	gateway := mock_pb.NewMockGatewayClient(ctrl)
+
+	schema, err := CompileVariablesSchema(orderSchema)
+	require.NoError(t, err)
+
+	client := configuredJobClient{
+		gatewayClient:   gateway,
+		shouldRetry:     noRetry,
+		completeOptions: []commands.CommandOption{commands.WithVariablesValidator(schema.Validate)},
+	}
+
+	var invalidErr *InvalidVariablesError
+
+	_, err = client.NewCompleteJobCommand().JobKey(1).VariablesFromMap(map[string]interface{}{"amount": 1})
+	assert.True(t, errors.As(err, &invalidErr))
+
+	_, err = client.NewCompleteJobCommand().JobKey(1).Send(context.Background())
+	assert.True(t, errors.As(err, &invalidErr))
+}
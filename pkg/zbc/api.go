@@ -50,3 +50,15 @@ type Client interface {
 
 	Close() error
 }
+
+// CapabilitiesProvider is implemented by clients which know what the gateway supports, like the one returned by
+// NewClient. It is kept apart from Client so that other implementations of Client are not required to provide it:
+//
+//	if provider, ok := client.(zbc.CapabilitiesProvider); ok && !provider.Capabilities().Supports(zbc.FeatureDeleteResource) {
+//		...
+//	}
+type CapabilitiesProvider interface {
+	// Capabilities returns what the gateway is known to support, based on the version it reported in the last
+	// topology response
+	Capabilities() Capabilities
+}
@@ -15,10 +15,13 @@
 package zbc
 
 import (
+	"context"
 	"crypto/tls"
 	"errors"
 	"fmt"
+	"io"
 	"log"
+	"net"
 	"os"
 	"strconv"
 	"strings"
@@ -28,11 +31,13 @@ import (
 	"google.golang.org/grpc/credentials/insecure"
 
 	"google.golang.org/grpc/credentials"
+	_ "google.golang.org/grpc/health" // registers the client-side health check function
 	"google.golang.org/grpc/keepalive"
 
 	"google.golang.org/grpc"
 
 	"github.com/camunda-community-hub/zeebe-client-go/v8/pkg/commands"
+	"github.com/camunda-community-hub/zeebe-client-go/v8/pkg/entities"
 	"github.com/camunda-community-hub/zeebe-client-go/v8/pkg/pb"
 	"github.com/camunda-community-hub/zeebe-client-go/v8/pkg/worker"
 )
@@ -47,6 +52,19 @@ const GatewayAddressEnvVar = "ZEEBE_ADDRESS"
 const GatewayHostEnvVar = "ZEEBE_HOST"
 const GatewayPortEnvVar = "ZEEBE_PORT"
 const OverrideAuthorityEnvVar = "ZEEBE_OVERRIDE_AUTHORITY"
+const GatewayAddressesEnvVar = "ZEEBE_GATEWAY_ADDRESSES"
+const LoadBalancingPolicyEnvVar = "ZEEBE_LOAD_BALANCING_POLICY"
+
+// PickFirstLoadBalancing sends all calls to the first reachable gateway, and only moves to the next one when the
+// connection to it fails. This is gRPC's default policy.
+const PickFirstLoadBalancing = "pick_first"
+
+// RoundRobinLoadBalancing spreads calls over all reachable gateways. It is the default when more than one gateway
+// address is configured.
+const RoundRobinLoadBalancing = "round_robin"
+
+// GatewayHealthCheckService is the service name the gateway reports its health for over the gRPC health protocol.
+const GatewayHealthCheckService = "gateway_protocol.Gateway"
 
 // Version specifies the client's version; this is used as part of the user agent string, for example
 var Version = embedded.Version
@@ -55,10 +73,35 @@ type ClientImpl struct {
 	gateway             pb.GatewayClient
 	connection          *grpc.ClientConn
 	credentialsProvider CredentialsProvider
+	// ownsCredentialsProvider is true if the credentials provider was created by the client, which closes it
+	ownsCredentialsProvider bool
+	capabilities            *gatewayCapabilities
+	serializer              entities.Serializer
+	claimCheck              *entities.ClaimCheck
+	maxVariablesSize        int
 }
 
 type ClientConfig struct {
-	GatewayAddress         string
+	// GatewayAddress is the host:port of a single gateway. Any gRPC target is accepted, e.g. 'dns:///gateway:26500'
+	// resolves all addresses of the gateway host, which can then be balanced over with LoadBalancingPolicy.
+	GatewayAddress string
+	// GatewayAddresses is an optional list of gateway host:port addresses. If it is non-empty, it takes precedence over
+	// GatewayAddress and the client balances its calls over all of them according to LoadBalancingPolicy. Can be
+	// overridden with the environment variable 'ZEEBE_GATEWAY_ADDRESSES' as a comma-separated list.
+	GatewayAddresses []string
+	// LoadBalancingPolicy is either PickFirstLoadBalancing or RoundRobinLoadBalancing. Defaults to round-robin if more
+	// than one gateway address is configured, and to gRPC's default otherwise. Can be overridden with the environment
+	// variable 'ZEEBE_LOAD_BALANCING_POLICY'.
+	//
+	// The policy is applied per call, so each job stream opened by a streaming job worker goes to the next gateway as
+	// well; set a finite JobWorkerBuilder.StreamRequestTimeout to rebalance long-lived streams over time.
+	LoadBalancingPolicy string
+	// HealthCheckEnabled makes the client watch the health of each gateway via the gRPC health protocol, and stop
+	// sending calls to a gateway which reports that it is not serving until it recovers. gRPC only checks the health
+	// of gateways with the round-robin policy, which is therefore used if LoadBalancingPolicy is not set, even with a
+	// single gateway address. Combining it with PickFirstLoadBalancing is rejected.
+	HealthCheckEnabled bool
+
 	UsePlaintextConnection bool
 	CaCertificatePath      string
 	OverrideAuthority      string
@@ -73,6 +116,35 @@ type ClientConfig struct {
 	// gRPC request. Defaults to zeebe-client-go/%version.
 	UserAgent string
 	DialOpts  []grpc.DialOption
+
+	// UnaryInterceptors and StreamInterceptors are invoked for every unary and streaming gRPC call made by the client,
+	// e.g. for logging, tracing or fault injection. They run in the given order, the first one being the outermost,
+	// after any interceptors given through DialOpts.
+	//
+	// Interceptors wrap a single attempt of a call: when a command is retried because the CredentialsProvider asked for
+	// it, the interceptors are invoked again for the new attempt. The credentials themselves are added by the transport
+	// after all interceptors ran, so they are not part of the outgoing metadata seen by an interceptor.
+	UnaryInterceptors  []grpc.UnaryClientInterceptor
+	StreamInterceptors []grpc.StreamClientInterceptor
+
+	// CheckGatewayVersion makes NewClient send a topology request to learn the gateway version, which is then exposed
+	// through Client.Capabilities. Calls to RPCs which the gateway is too old to support fail with an
+	// UnsupportedByGatewayError without being sent. NewClient fails if the gateway cannot be reached within
+	// DefaultGatewayVersionCheckTimeout.
+	CheckGatewayVersion bool
+
+	// Serializer encodes the variables sent by commands, and decodes the variables of activated jobs read through
+	// worker.JobVariables. Job.GetVariablesAs and Job.GetVariablesAsMap bypass it and always use encoding/json. Job
+	// workers use it unless they set their own. Defaults to entities.JSONSerializer, based on encoding/json.
+	Serializer entities.Serializer
+	// ClaimCheck offloads variables larger than its threshold to a blob store when commands are sent, replacing them by
+	// references. Job workers resolve the references before calling their handler, unless they set their own claim
+	// check, and worker.JobVariables resolves them when the variables are read. Disabled if nil.
+	ClaimCheck *entities.ClaimCheck
+	// MaxVariablesSize makes commands fail with a *commands.VariablesTooLargeError if the variables are larger than
+	// this many bytes, e.g. the maxMessageSize of the brokers. The size is checked by the VariablesFrom* methods, or by
+	// Send after offloading if ClaimCheck is set. Zero means no limit.
+	MaxVariablesSize int
 }
 
 // ErrFileNotFound is returned whenever a file can't be found at the provided path. Use this value to do error comparison.
@@ -97,15 +169,15 @@ func (c *ClientImpl) NewDeployResourceCommand() *commands.DeployResourceCommand
 }
 
 func (c *ClientImpl) NewEvaluateDecisionCommand() commands.EvaluateDecisionCommandStep1 {
-	return commands.NewEvaluateDecisionCommand(c.gateway, c.credentialsProvider.ShouldRetryRequest)
+	return commands.NewEvaluateDecisionCommand(c.gateway, c.credentialsProvider.ShouldRetryRequest, c.commandOptions()...)
 }
 
 func (c *ClientImpl) NewPublishMessageCommand() commands.PublishMessageCommandStep1 {
-	return commands.NewPublishMessageCommand(c.gateway, c.credentialsProvider.ShouldRetryRequest)
+	return commands.NewPublishMessageCommand(c.gateway, c.credentialsProvider.ShouldRetryRequest, c.commandOptions()...)
 }
 
 func (c *ClientImpl) NewBroadcastSignalCommand() commands.BroadcastSignalCommandStep1 {
-	return commands.NewBroadcastSignalCommand(c.gateway, c.credentialsProvider.ShouldRetryRequest)
+	return commands.NewBroadcastSignalCommand(c.gateway, c.credentialsProvider.ShouldRetryRequest, c.commandOptions()...)
 }
 
 func (c *ClientImpl) NewResolveIncidentCommand() commands.ResolveIncidentCommandStep1 {
@@ -113,7 +185,7 @@ func (c *ClientImpl) NewResolveIncidentCommand() commands.ResolveIncidentCommand
 }
 
 func (c *ClientImpl) NewCreateInstanceCommand() commands.CreateInstanceCommandStep1 {
-	return commands.NewCreateInstanceCommand(c.gateway, c.credentialsProvider.ShouldRetryRequest)
+	return commands.NewCreateInstanceCommand(c.gateway, c.credentialsProvider.ShouldRetryRequest, c.commandOptions()...)
 }
 
 func (c *ClientImpl) NewCancelInstanceCommand() commands.CancelInstanceStep1 {
@@ -121,11 +193,11 @@ func (c *ClientImpl) NewCancelInstanceCommand() commands.CancelInstanceStep1 {
 }
 
 func (c *ClientImpl) NewCompleteJobCommand() commands.CompleteJobCommandStep1 {
-	return commands.NewCompleteJobCommand(c.gateway, c.credentialsProvider.ShouldRetryRequest)
+	return commands.NewCompleteJobCommand(c.gateway, c.credentialsProvider.ShouldRetryRequest, c.commandOptions()...)
 }
 
 func (c *ClientImpl) NewFailJobCommand() commands.FailJobCommandStep1 {
-	return commands.NewFailJobCommand(c.gateway, c.credentialsProvider.ShouldRetryRequest)
+	return commands.NewFailJobCommand(c.gateway, c.credentialsProvider.ShouldRetryRequest, c.commandOptions()...)
 }
 
 func (c *ClientImpl) NewUpdateJobRetriesCommand() commands.UpdateJobRetriesCommandStep1 {
@@ -137,15 +209,15 @@ func (c *ClientImpl) NewUpdateJobTimeoutCommand() commands.UpdateJobTimeoutComma
 }
 
 func (c *ClientImpl) NewSetVariablesCommand() commands.SetVariablesCommandStep1 {
-	return commands.NewSetVariablesCommand(c.gateway, c.credentialsProvider.ShouldRetryRequest)
+	return commands.NewSetVariablesCommand(c.gateway, c.credentialsProvider.ShouldRetryRequest, c.commandOptions()...)
 }
 
 func (c *ClientImpl) NewActivateJobsCommand() commands.ActivateJobsCommandStep1 {
-	return commands.NewActivateJobsCommand(c.gateway, c.credentialsProvider.ShouldRetryRequest)
+	return commands.NewActivateJobsCommand(c.gateway, c.credentialsProvider.ShouldRetryRequest, c.commandOptions()...)
 }
 
 func (c *ClientImpl) NewThrowErrorCommand() commands.ThrowErrorCommandStep1 {
-	return commands.NewThrowErrorCommand(c.gateway, c.credentialsProvider.ShouldRetryRequest)
+	return commands.NewThrowErrorCommand(c.gateway, c.credentialsProvider.ShouldRetryRequest, c.commandOptions()...)
 }
 
 func (c *ClientImpl) NewDeleteResourceCommand() commands.DeleteResourceCommandStep1 {
@@ -153,14 +225,46 @@ func (c *ClientImpl) NewDeleteResourceCommand() commands.DeleteResourceCommandSt
 }
 
 func (c *ClientImpl) NewStreamJobsCommand() commands.StreamJobsCommandStep1 {
-	return commands.NewStreamJobsCommand(c.gateway, c.credentialsProvider.ShouldRetryRequest)
+	return commands.NewStreamJobsCommand(c.gateway, c.credentialsProvider.ShouldRetryRequest, c.commandOptions()...)
 }
 
 func (c *ClientImpl) NewJobWorker() worker.JobWorkerBuilderStep1 {
-	return worker.NewJobWorkerBuilder(c.gateway, c, c.credentialsProvider.ShouldRetryRequest)
+	builder := worker.NewJobWorkerBuilder(c.gateway, c, c.credentialsProvider.ShouldRetryRequest).(*worker.JobWorkerBuilder)
+	if c.serializer != nil {
+		builder.Serializer(c.serializer)
+	}
+	if c.claimCheck != nil {
+		builder.ClaimCheck(c.claimCheck)
+	}
+	if c.maxVariablesSize > 0 {
+		builder.MaxVariablesSize(c.maxVariablesSize)
+	}
+	return builder
+}
+
+// commandOptions returns the options of the commands handling variables
+func (c *ClientImpl) commandOptions() []commands.CommandOption {
+	return []commands.CommandOption{
+		commands.WithSerializer(c.serializer),
+		commands.WithClaimCheck(c.claimCheck),
+		commands.WithMaxVariablesSize(c.maxVariablesSize),
+	}
+}
+
+// NewJobVariables returns the variables of the job, read with the serializer and claim check of the client
+func (c *ClientImpl) NewJobVariables(job entities.Job) *entities.JobVariables {
+	return entities.NewJobVariables(job, c.serializer, c.claimCheck)
+}
+
+// Capabilities returns what the gateway is known to support, see ClientConfig.CheckGatewayVersion
+func (c *ClientImpl) Capabilities() Capabilities {
+	return c.capabilities.get()
 }
 
 func (c *ClientImpl) Close() error {
+	if closer, ok := c.credentialsProvider.(io.Closer); ok && c.ownsCredentialsProvider {
+		_ = closer.Close()
+	}
 	return c.connection.Close()
 }
 
@@ -175,6 +279,7 @@ func NewClient(config *ClientConfig) (Client, error) {
 		return nil, err
 	}
 
+	ownsCredentialsProvider := config.CredentialsProvider == nil
 	err = configureCredentialsProvider(config)
 	if err != nil {
 		return nil, err
@@ -185,22 +290,56 @@ func NewClient(config *ClientConfig) (Client, error) {
 		return nil, err
 	}
 
+	target, err := configureLoadBalancing(config)
+	if err != nil {
+		return nil, err
+	}
+
+	capabilities := &gatewayCapabilities{}
+	configureInterceptors(config, capabilities)
+
 	if config.UserAgent == "" {
 		config.UserAgent = "zeebe-client-go/" + Version
 	}
 
 	config.DialOpts = append(config.DialOpts, grpc.WithUserAgent(config.UserAgent))
 
-	conn, err := grpc.NewClient(config.GatewayAddress, config.DialOpts...)
+	conn, err := grpc.NewClient(target, config.DialOpts...)
 	if err != nil {
 		return nil, err
 	}
 
-	return &ClientImpl{
-		gateway:             pb.NewGatewayClient(conn),
-		connection:          conn,
-		credentialsProvider: config.CredentialsProvider,
-	}, nil
+	client := &ClientImpl{
+		gateway:                 pb.NewGatewayClient(conn),
+		connection:              conn,
+		credentialsProvider:     config.CredentialsProvider,
+		ownsCredentialsProvider: ownsCredentialsProvider,
+		capabilities:            capabilities,
+		serializer:              config.Serializer,
+		claimCheck:              config.ClaimCheck,
+		maxVariablesSize:        config.MaxVariablesSize,
+	}
+
+	if config.CheckGatewayVersion {
+		if err := client.checkGatewayVersion(); err != nil {
+			_ = conn.Close()
+			return nil, err
+		}
+	}
+
+	return client, nil
+}
+
+func (c *ClientImpl) checkGatewayVersion() error {
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultGatewayVersionCheckTimeout)
+	defer cancel()
+
+	// the gateway version is recorded by the capabilities interceptor
+	if _, err := c.NewTopologyCommand().Send(ctx); err != nil {
+		return fmt.Errorf("failed to check gateway version: %w", err)
+	}
+
+	return nil
 }
 
 func applyClientEnvOverrides(config *ClientConfig) error {
@@ -216,6 +355,18 @@ func applyClientEnvOverrides(config *ClientConfig) error {
 		config.OverrideAuthority = overrideAuthority
 	}
 
+	if gatewayAddresses := env.get(GatewayAddressesEnvVar); gatewayAddresses != "" {
+		config.GatewayAddresses = nil
+		for _, address := range strings.Split(gatewayAddresses, ",") {
+			if address = strings.TrimSpace(address); address != "" {
+				config.GatewayAddresses = append(config.GatewayAddresses, address)
+			}
+		}
+	} else if env.get(GatewayHostEnvVar) != "" || env.get(GatewayPortEnvVar) != "" || env.get(GatewayAddressEnvVar) != "" {
+		// a single address given through the environment replaces the configured ones
+		config.GatewayAddresses = nil
+	}
+
 	if gatewayHost := env.get(GatewayHostEnvVar); gatewayHost != "" {
 		if gatewayPort := env.get(GatewayPortEnvVar); gatewayPort != "" {
 			config.GatewayAddress = fmt.Sprintf("%s:%s", gatewayHost, gatewayPort)
@@ -228,6 +379,10 @@ func applyClientEnvOverrides(config *ClientConfig) error {
 		config.GatewayAddress = gatewayAddress
 	}
 
+	if loadBalancingPolicy := env.get(LoadBalancingPolicyEnvVar); loadBalancingPolicy != "" {
+		config.LoadBalancingPolicy = loadBalancingPolicy
+	}
+
 	if val := env.get(KeepAliveEnvVar); val != "" {
 		keepAlive, err := strconv.ParseUint(val, 10, 64)
 		if err != nil {
@@ -262,14 +417,40 @@ func configureCredentialsProvider(config *ClientConfig) error {
 }
 
 func shouldUseDefaultCredentialsProvider() bool {
-	return env.get(OAuthClientSecretEnvVar) != "" || env.get(OAuthClientIdEnvVar) != ""
+	return shouldUseOAuthCredentialsProvider() || env.get(BearerTokenEnvVar) != "" || env.get(APIKeyEnvVar) != "" || env.get(TokenPathEnvVar) != ""
 }
 
-func setDefaultCredentialsProvider(config *ClientConfig) error {
+func shouldUseOAuthCredentialsProvider() bool {
+	return env.get(OAuthClientSecretEnvVar) != "" || env.get(OAuthClientIdEnvVar) != "" || env.get(OAuthSubjectTokenPathEnvVar) != ""
+}
+
+// sets the credentials provider configured through environment variables, preferring OAuth over a bearer token, an API
+// key and a token file, in that order
+func setDefaultCredentialsProvider(config *ClientConfig) (err error) {
+	switch {
+	case shouldUseOAuthCredentialsProvider():
+		return setDefaultOAuthCredentialsProvider(config)
+	case env.get(BearerTokenEnvVar) != "":
+		config.CredentialsProvider, err = NewBearerTokenCredentialsProvider(env.get(BearerTokenEnvVar))
+	case env.get(APIKeyEnvVar) != "":
+		config.CredentialsProvider, err = NewAPIKeyCredentialsProvider(env.get(APIKeyHeaderEnvVar), env.get(APIKeyEnvVar))
+	default:
+		config.CredentialsProvider, err = NewFileTokenCredentialsProvider(env.get(TokenPathEnvVar))
+	}
+
+	return err
+}
+
+func setDefaultOAuthCredentialsProvider(config *ClientConfig) error {
 	var audience string
-	index := strings.LastIndex(config.GatewayAddress, ":")
+	address := config.GatewayAddress
+	if len(config.GatewayAddresses) > 0 {
+		address = config.GatewayAddresses[0]
+	}
+
+	index := strings.LastIndex(address, ":")
 	if index > 0 {
-		audience = config.GatewayAddress[0:index]
+		audience = address[0:index]
 	}
 
 	provider, err := NewOAuthCredentialsProvider(&OAuthProviderConfig{Audience: audience})
@@ -281,6 +462,15 @@ func setDefaultCredentialsProvider(config *ClientConfig) error {
 	return nil
 }
 
+// the capabilities interceptors run last, so that the configured interceptors observe UnsupportedByGatewayError
+func configureInterceptors(config *ClientConfig, capabilities *gatewayCapabilities) {
+	unaryInterceptors := append(append([]grpc.UnaryClientInterceptor{}, config.UnaryInterceptors...), capabilities.interceptUnary)
+	config.DialOpts = append(config.DialOpts, grpc.WithChainUnaryInterceptor(unaryInterceptors...))
+
+	streamInterceptors := append(append([]grpc.StreamClientInterceptor{}, config.StreamInterceptors...), capabilities.interceptStream)
+	config.DialOpts = append(config.DialOpts, grpc.WithChainStreamInterceptor(streamInterceptors...))
+}
+
 func configureConnectionSecurity(config *ClientConfig) error {
 	if !config.UsePlaintextConnection {
 		var creds credentials.TransportCredentials
@@ -316,3 +506,56 @@ func configureKeepAlive(config *ClientConfig) error {
 
 	return nil
 }
+
+// configureLoadBalancing sets up the resolver and service config for the configured gateway addresses and load
+// balancing policy, and returns the target the connection should be created for.
+func configureLoadBalancing(config *ClientConfig) (string, error) {
+	target := config.GatewayAddress
+	policy := config.LoadBalancingPolicy
+
+	if len(config.GatewayAddresses) == 1 {
+		target = config.GatewayAddresses[0]
+	} else if len(config.GatewayAddresses) > 1 {
+		for _, address := range config.GatewayAddresses {
+			if _, _, err := net.SplitHostPort(address); err != nil {
+				return "", fmt.Errorf("expected gateway address '%s' to be of the form host:port: %w", address, err)
+			}
+		}
+
+		target = gatewayResolverScheme + ":///"
+		config.DialOpts = append(config.DialOpts, grpc.WithResolvers(&gatewayResolverBuilder{addresses: config.GatewayAddresses}))
+
+		if policy == "" {
+			policy = RoundRobinLoadBalancing
+		}
+	}
+
+	if config.HealthCheckEnabled {
+		// gRPC ignores the health check config with balancers other than round-robin
+		if policy == "" {
+			policy = RoundRobinLoadBalancing
+		} else if policy == PickFirstLoadBalancing {
+			return "", fmt.Errorf("expected load balancing policy '%s' with health checks enabled, but got '%s'", RoundRobinLoadBalancing, policy)
+		}
+	}
+
+	switch policy {
+	case "", PickFirstLoadBalancing, RoundRobinLoadBalancing:
+	default:
+		return "", fmt.Errorf("expected load balancing policy to be one of '%s' or '%s', but got '%s'", PickFirstLoadBalancing, RoundRobinLoadBalancing, policy)
+	}
+
+	var serviceConfig []string
+	if policy != "" {
+		serviceConfig = append(serviceConfig, fmt.Sprintf(`"loadBalancingConfig":[{%q:{}}]`, policy))
+	}
+	if config.HealthCheckEnabled {
+		serviceConfig = append(serviceConfig, fmt.Sprintf(`"healthCheckConfig":{"serviceName":%q}`, GatewayHealthCheckService))
+	}
+
+	if len(serviceConfig) > 0 {
+		config.DialOpts = append(config.DialOpts, grpc.WithDefaultServiceConfig("{"+strings.Join(serviceConfig, ",")+"}"))
+	}
+
+	return target, nil
+}
@@ -16,6 +16,7 @@ package zbc
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net"
@@ -545,6 +546,239 @@ func (s *clientTestSuite) TestOverrideHostAndPortEnvVar() {
 	s.EqualValues(fmt.Sprintf("%s:%s", address, port), config.GatewayAddress)
 }
 
+func (s *clientTestSuite) TestGatewayAddressesRoundRobin() {
+	// given
+	firstCalls, secondCalls := 0, 0
+	firstLis, firstServer := createServerWithUnaryInterceptor(countingInterceptor(&firstCalls))
+	go firstServer.Serve(firstLis)
+	defer firstServer.Stop()
+
+	secondLis, secondServer := createServerWithUnaryInterceptor(countingInterceptor(&secondCalls))
+	go secondServer.Serve(secondLis)
+	defer secondServer.Stop()
+
+	client, err := NewClient(&ClientConfig{
+		GatewayAddresses:       []string{firstLis.Addr().String(), secondLis.Addr().String()},
+		UsePlaintextConnection: true,
+	})
+	s.Require().NoError(err)
+	defer client.Close()
+
+	// when
+	ctx, cancel := context.WithTimeout(context.Background(), utils.DefaultTestTimeout)
+	defer cancel()
+
+	for i := 0; i < 50 && (firstCalls == 0 || secondCalls == 0); i++ {
+		_, _ = client.NewTopologyCommand().Send(ctx)
+	}
+
+	// then
+	s.Positive(firstCalls)
+	s.Positive(secondCalls)
+}
+
+func (s *clientTestSuite) TestGatewayAddressesEnvVar() {
+	// given
+	env.set(GatewayAddressesEnvVar, "first:26500, second:26500")
+	config := &ClientConfig{
+		GatewayAddress:         "wrong_address",
+		UsePlaintextConnection: true,
+	}
+
+	// when
+	_, err := NewClient(config)
+
+	// then
+	s.NoError(err)
+	s.Equal([]string{"first:26500", "second:26500"}, config.GatewayAddresses)
+}
+
+func (s *clientTestSuite) TestGatewayAddressEnvVarReplacesGatewayAddresses() {
+	// given
+	env.set(GatewayAddressEnvVar, "single:26500")
+	config := &ClientConfig{
+		GatewayAddresses:       []string{"first:26500", "second:26500"},
+		UsePlaintextConnection: true,
+	}
+
+	// when
+	_, err := NewClient(config)
+
+	// then
+	s.NoError(err)
+	s.Empty(config.GatewayAddresses)
+	s.Equal("single:26500", config.GatewayAddress)
+}
+
+func (s *clientTestSuite) TestRejectMalformedGatewayAddresses() {
+	// given
+	config := &ClientConfig{
+		GatewayAddresses:       []string{"first:26500", "second"},
+		UsePlaintextConnection: true,
+	}
+
+	// when
+	_, err := NewClient(config)
+
+	// then
+	s.Error(err)
+}
+
+func (s *clientTestSuite) TestRejectUnknownLoadBalancingPolicy() {
+	// given
+	env.set(LoadBalancingPolicyEnvVar, "random")
+	config := &ClientConfig{
+		GatewayAddress:         "0.0.0.0:0",
+		UsePlaintextConnection: true,
+	}
+
+	// when
+	_, err := NewClient(config)
+
+	// then
+	s.Error(err)
+}
+
+func (s *clientTestSuite) TestHealthCheckWithGatewayAddresses() {
+	// given
+	lis, grpcServer := createServerWithDefaultAddress()
+	go grpcServer.Serve(lis)
+	defer grpcServer.Stop()
+
+	client, err := NewClient(&ClientConfig{
+		GatewayAddresses:       []string{lis.Addr().String(), lis.Addr().String()},
+		UsePlaintextConnection: true,
+		HealthCheckEnabled:     true,
+	})
+	s.Require().NoError(err)
+	defer client.Close()
+
+	// when
+	ctx, cancel := context.WithTimeout(context.Background(), utils.DefaultTestTimeout)
+	defer cancel()
+
+	_, err = client.NewTopologyCommand().Send(ctx)
+
+	// then - the gateway doesn't implement the health service, which gRPC treats as healthy
+	s.EqualValues(codes.Unimplemented, status.Code(err))
+}
+
+func (s *clientTestSuite) TestHealthCheckRequiresRoundRobin() {
+	// when
+	_, err := NewClient(&ClientConfig{
+		GatewayAddress:         "0.0.0.0:0",
+		UsePlaintextConnection: true,
+		HealthCheckEnabled:     true,
+		LoadBalancingPolicy:    PickFirstLoadBalancing,
+	})
+
+	// then
+	s.ErrorContains(err, "health checks enabled")
+}
+
+func (s *clientTestSuite) TestHealthCheckWithSingleGatewayAddress() {
+	// given
+	lis, grpcServer := createServerWithDefaultAddress()
+	go grpcServer.Serve(lis)
+	defer grpcServer.Stop()
+
+	client, err := NewClient(&ClientConfig{
+		GatewayAddress:         lis.Addr().String(),
+		UsePlaintextConnection: true,
+		HealthCheckEnabled:     true,
+	})
+	s.Require().NoError(err)
+	defer client.Close()
+
+	// when
+	ctx, cancel := context.WithTimeout(context.Background(), utils.DefaultTestTimeout)
+	defer cancel()
+
+	_, err = client.NewTopologyCommand().Send(ctx)
+
+	// then
+	s.EqualValues(codes.Unimplemented, status.Code(err))
+}
+
+func (s *clientTestSuite) TestUnaryInterceptors() {
+	// given
+	lis, grpcServer := createServerWithDefaultAddress()
+	go grpcServer.Serve(lis)
+	defer grpcServer.Stop()
+
+	var calls []string
+	recordCall := func(name string) grpc.UnaryClientInterceptor {
+		return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+			calls = append(calls, name+" "+method)
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+	}
+
+	retried := false
+	client, err := NewClient(&ClientConfig{
+		GatewayAddress:         lis.Addr().String(),
+		UsePlaintextConnection: true,
+		CredentialsProvider: &customCredentialsProvider{
+			customToken: accessToken,
+			retryPredicate: func(error) bool {
+				retry := !retried
+				retried = true
+				return retry
+			},
+		},
+		DialOpts:          []grpc.DialOption{grpc.WithUnaryInterceptor(recordCall("dialOpt"))},
+		UnaryInterceptors: []grpc.UnaryClientInterceptor{recordCall("first"), recordCall("second")},
+	})
+	s.Require().NoError(err)
+	defer client.Close()
+
+	// when
+	_, err = client.NewTopologyCommand().Send(context.Background())
+
+	// then - each attempt passes through all interceptors in order
+	s.EqualValues(codes.Unimplemented, status.Code(err))
+	method := "/gateway_protocol.Gateway/Topology"
+	s.Equal([]string{
+		"dialOpt " + method, "first " + method, "second " + method,
+		"dialOpt " + method, "first " + method, "second " + method,
+	}, calls)
+}
+
+func (s *clientTestSuite) TestStreamInterceptors() {
+	// given
+	lis, grpcServer := createServerWithDefaultAddress()
+	go grpcServer.Serve(lis)
+	defer grpcServer.Stop()
+
+	var methods []string
+	client, err := NewClient(&ClientConfig{
+		GatewayAddress:         lis.Addr().String(),
+		UsePlaintextConnection: true,
+		StreamInterceptors: []grpc.StreamClientInterceptor{
+			func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+				methods = append(methods, method)
+				return streamer(ctx, desc, cc, method, opts...)
+			},
+		},
+	})
+	s.Require().NoError(err)
+	defer client.Close()
+
+	// when
+	_, err = client.NewActivateJobsCommand().JobType("foo").MaxJobsToActivate(1).Send(context.Background())
+
+	// then
+	s.EqualValues(codes.Unimplemented, status.Code(err))
+	s.Equal([]string{"/gateway_protocol.Gateway/ActivateJobs"}, methods)
+}
+
+func countingInterceptor(counter *int) grpc.UnaryServerInterceptor {
+	return func(_ context.Context, _ interface{}, _ *grpc.UnaryServerInfo, _ grpc.UnaryHandler) (interface{}, error) {
+		*counter++
+		return nil, status.Error(codes.Unimplemented, "expected")
+	}
+}
+
 func createSecureServer(withSan bool) (net.Listener, *grpc.Server) {
 	certFile := "testdata/chain.cert.pem"
 	keyFile := "testdata/private.key.pem"
@@ -568,3 +802,48 @@ func createServer(address string, port string, opts ...grpc.ServerOption) (net.L
 	pb.RegisterGatewayServer(grpcServer, &pb.UnimplementedGatewayServer{})
 	return lis, grpcServer
 }
+
+// upperCaseSerializer encodes variables with upper case keys, to tell it apart from encoding/json
+type upperCaseSerializer struct{}
+
+func (upperCaseSerializer) Marshal(v interface{}) ([]byte, error) {
+	b, err := json.Marshal(v)
+	return []byte(strings.ToUpper(string(b))), err
+}
+
+func (upperCaseSerializer) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+func (s *clientTestSuite) TestSerializer() {
+	// given
+	lis, grpcServer := createServerWithDefaultAddress()
+	go grpcServer.Serve(lis)
+	defer grpcServer.Stop()
+
+	var variables string
+	client, err := NewClient(&ClientConfig{
+		GatewayAddress:         lis.Addr().String(),
+		UsePlaintextConnection: true,
+		Serializer:             upperCaseSerializer{},
+		UnaryInterceptors: []grpc.UnaryClientInterceptor{
+			func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+				variables = req.(*pb.CompleteJobRequest).GetVariables()
+				return invoker(ctx, method, req, reply, cc, opts...)
+			},
+		},
+	})
+	s.Require().NoError(err)
+	defer client.Close()
+
+	command, err := client.NewCompleteJobCommand().JobKey(1).VariablesFromMap(map[string]interface{}{"foo": "bar"})
+	s.Require().NoError(err)
+
+	// when
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	_, _ = command.Send(ctx)
+
+	// then
+	s.Equal(`{"FOO":"BAR"}`, variables)
+}
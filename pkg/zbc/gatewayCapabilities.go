@@ -0,0 +1,249 @@
+// Copyright © 2018 Camunda Services GmbH (info@camunda.com)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package zbc
+
+import (
+	"context"
+	"fmt"
+	"github.com/camunda-community-hub/zeebe-client-go/v8/pkg/pb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultGatewayVersionCheckTimeout is the timeout of the topology request sent by NewClient if
+// ClientConfig.CheckGatewayVersion is enabled
+const DefaultGatewayVersionCheckTimeout = 10 * time.Second
+
+// ErrUnsupportedByGateway is matched by errors.Is for every UnsupportedByGatewayError
+const ErrUnsupportedByGateway = Error("operation is not supported by the gateway")
+
+// GatewayFeature is a gateway RPC which is not available in all gateway versions
+type GatewayFeature string
+
+const (
+	FeatureModifyProcessInstance  GatewayFeature = "ModifyProcessInstance"
+	FeatureEvaluateDecision       GatewayFeature = "EvaluateDecision"
+	FeatureBroadcastSignal        GatewayFeature = "BroadcastSignal"
+	FeatureDeleteResource         GatewayFeature = "DeleteResource"
+	FeatureStreamActivatedJobs    GatewayFeature = "StreamActivatedJobs"
+	FeatureMigrateProcessInstance GatewayFeature = "MigrateProcessInstance"
+	FeatureUpdateJobTimeout       GatewayFeature = "UpdateJobTimeout"
+)
+
+// the first gateway version which supports each feature
+var gatewayFeatureVersions = map[GatewayFeature]string{
+	FeatureModifyProcessInstance:  "8.1.0",
+	FeatureEvaluateDecision:       "8.2.0",
+	FeatureBroadcastSignal:        "8.2.0",
+	FeatureDeleteResource:         "8.3.0",
+	FeatureStreamActivatedJobs:    "8.4.0",
+	FeatureMigrateProcessInstance: "8.4.0",
+	FeatureUpdateJobTimeout:       "8.5.0",
+}
+
+const gatewayMethodPrefix = "/gateway_protocol.Gateway/"
+
+// Capabilities describes what the gateway the client is connected to supports. The gateway version is learned from
+// the response of any topology request, e.g. the one sent by NewClient if ClientConfig.CheckGatewayVersion is enabled.
+type Capabilities struct {
+	// GatewayVersion as reported by the gateway, or empty if it is not known yet
+	GatewayVersion string
+}
+
+// Supports returns whether the gateway supports the given feature. If the gateway version is not known or cannot be
+// parsed, every feature is assumed to be supported.
+func (c Capabilities) Supports(feature GatewayFeature) bool {
+	requiredVersion, ok := gatewayFeatureVersions[feature]
+	if !ok {
+		return true
+	}
+
+	gatewayVersion, ok := parseGatewayVersion(c.GatewayVersion)
+	if !ok {
+		return true
+	}
+
+	required, _ := parseGatewayVersion(requiredVersion)
+	for i := range gatewayVersion {
+		if gatewayVersion[i] != required[i] {
+			return gatewayVersion[i] > required[i]
+		}
+	}
+
+	return true
+}
+
+// UnsupportedByGatewayError is returned for calls to RPCs which the gateway does not implement, either because the
+// gateway answered with UNIMPLEMENTED, or because its version is known to be too old, in which case the call is not
+// sent at all. It still reports codes.Unimplemented through status.Code.
+type UnsupportedByGatewayError struct {
+	// Feature is the RPC which is not supported
+	Feature GatewayFeature
+	// GatewayVersion is the version of the gateway, or empty if it is not known
+	GatewayVersion string
+	// RequiredVersion is the first gateway version which supports the feature, or empty if it is not known
+	RequiredVersion string
+	// Err is the error returned by the gateway, or nil if the call was not sent
+	Err error
+}
+
+func (e *UnsupportedByGatewayError) Error() string {
+	message := fmt.Sprintf("%s: %s", ErrUnsupportedByGateway, e.Feature)
+	if e.GatewayVersion != "" {
+		message += fmt.Sprintf(" (gateway version %s", e.GatewayVersion)
+		if e.RequiredVersion != "" {
+			message += fmt.Sprintf(", requires %s", e.RequiredVersion)
+		}
+		message += ")"
+	}
+	if e.Err != nil {
+		message += ": " + e.Err.Error()
+	}
+
+	return message
+}
+
+func (e *UnsupportedByGatewayError) Is(target error) bool {
+	return target == ErrUnsupportedByGateway
+}
+
+func (e *UnsupportedByGatewayError) Unwrap() error {
+	return e.Err
+}
+
+func (e *UnsupportedByGatewayError) GRPCStatus() *status.Status {
+	return status.New(codes.Unimplemented, e.Error())
+}
+
+// gatewayCapabilities records the gateway version from topology responses, and translates calls to unsupported RPCs
+// into UnsupportedByGatewayError
+type gatewayCapabilities struct {
+	lock           sync.RWMutex
+	gatewayVersion string
+}
+
+func (c *gatewayCapabilities) get() Capabilities {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	return Capabilities{GatewayVersion: c.gatewayVersion}
+}
+
+func (c *gatewayCapabilities) setGatewayVersion(version string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.gatewayVersion = version
+}
+
+func (c *gatewayCapabilities) interceptUnary(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	if err := c.checkSupported(method); err != nil {
+		return err
+	}
+
+	if err := invoker(ctx, method, req, reply, cc, opts...); err != nil {
+		return c.translateError(method, err)
+	}
+
+	if topology, ok := reply.(*pb.TopologyResponse); ok && topology.GatewayVersion != "" {
+		c.setGatewayVersion(topology.GatewayVersion)
+	}
+
+	return nil
+}
+
+func (c *gatewayCapabilities) interceptStream(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	if err := c.checkSupported(method); err != nil {
+		return nil, err
+	}
+
+	stream, err := streamer(ctx, desc, cc, method, opts...)
+	if err != nil {
+		return nil, c.translateError(method, err)
+	}
+
+	return &capabilitiesClientStream{ClientStream: stream, capabilities: c, method: method}, nil
+}
+
+func (c *gatewayCapabilities) checkSupported(method string) error {
+	feature := GatewayFeature(strings.TrimPrefix(method, gatewayMethodPrefix))
+	if capabilities := c.get(); !capabilities.Supports(feature) {
+		return &UnsupportedByGatewayError{
+			Feature:         feature,
+			GatewayVersion:  capabilities.GatewayVersion,
+			RequiredVersion: gatewayFeatureVersions[feature],
+		}
+	}
+
+	return nil
+}
+
+func (c *gatewayCapabilities) translateError(method string, err error) error {
+	if status.Code(err) != codes.Unimplemented {
+		return err
+	}
+
+	feature := GatewayFeature(strings.TrimPrefix(method, gatewayMethodPrefix))
+	return &UnsupportedByGatewayError{
+		Feature:         feature,
+		GatewayVersion:  c.get().GatewayVersion,
+		RequiredVersion: gatewayFeatureVersions[feature],
+		Err:             err,
+	}
+}
+
+type capabilitiesClientStream struct {
+	grpc.ClientStream
+	capabilities *gatewayCapabilities
+	method       string
+}
+
+func (s *capabilitiesClientStream) RecvMsg(m interface{}) error {
+	if err := s.ClientStream.RecvMsg(m); err != nil {
+		return s.capabilities.translateError(s.method, err)
+	}
+
+	return nil
+}
+
+// parses the major, minor and patch version, ignoring any pre-release or build suffix
+func parseGatewayVersion(version string) ([3]int, bool) {
+	var parsed [3]int
+
+	version = strings.TrimPrefix(version, "v")
+	if index := strings.IndexAny(version, "-+"); index >= 0 {
+		version = version[:index]
+	}
+
+	parts := strings.Split(version, ".")
+	if len(parts) != len(parsed) {
+		return parsed, false
+	}
+
+	for i, part := range parts {
+		number, err := strconv.Atoi(part)
+		if err != nil {
+			return parsed, false
+		}
+		parsed[i] = number
+	}
+
+	return parsed, true
+}
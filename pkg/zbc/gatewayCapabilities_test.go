@@ -0,0 +1,187 @@
+// Copyright © 2018 Camunda Services GmbH (info@camunda.com)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package zbc
+
+import (
+	"context"
+	"errors"
+	"github.com/camunda-community-hub/zeebe-client-go/v8/pkg/pb"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"net"
+	"sync/atomic"
+	"testing"
+)
+
+type gatewayCapabilitiesTestSuite struct {
+	*envSuite
+}
+
+func TestGatewayCapabilitiesSuite(t *testing.T) {
+	suite.Run(t, &gatewayCapabilitiesTestSuite{new(envSuite)})
+}
+
+func (s *gatewayCapabilitiesTestSuite) TestSupports() {
+	for _, tc := range []struct {
+		version  string
+		feature  GatewayFeature
+		expected bool
+	}{
+		{"", FeatureStreamActivatedJobs, true},
+		{"unknown", FeatureStreamActivatedJobs, true},
+		{"8.3.9", FeatureStreamActivatedJobs, false},
+		{"8.4.0", FeatureStreamActivatedJobs, true},
+		{"8.4.0-SNAPSHOT", FeatureStreamActivatedJobs, true},
+		{"8.5.0-alpha1", FeatureUpdateJobTimeout, true},
+		{"8.4.4", FeatureUpdateJobTimeout, false},
+		{"7.99.0", FeatureModifyProcessInstance, false},
+		{"9.0.0", FeatureMigrateProcessInstance, true},
+		{"8.0.0", "Topology", true},
+	} {
+		capabilities := Capabilities{GatewayVersion: tc.version}
+		s.Equal(tc.expected, capabilities.Supports(tc.feature), "%s on gateway %q", tc.feature, tc.version)
+	}
+}
+
+func (s *gatewayCapabilitiesTestSuite) TestCheckGatewayVersion() {
+	// given
+	gateway := &versionedGateway{version: "8.3.5"}
+	lis, grpcServer := createVersionedGatewayServer(gateway)
+	go grpcServer.Serve(lis)
+	defer grpcServer.Stop()
+
+	// when
+	client, err := NewClient(&ClientConfig{
+		GatewayAddress:         lis.Addr().String(),
+		UsePlaintextConnection: true,
+		CheckGatewayVersion:    true,
+	})
+
+	// then
+	s.Require().NoError(err)
+	defer client.Close()
+	capabilities := client.(CapabilitiesProvider).Capabilities()
+	s.Equal("8.3.5", capabilities.GatewayVersion)
+	s.True(capabilities.Supports(FeatureDeleteResource))
+	s.False(capabilities.Supports(FeatureStreamActivatedJobs))
+}
+
+func (s *gatewayCapabilitiesTestSuite) TestCapabilitiesUnknownWithoutCheck() {
+	// given
+	client, err := NewClient(&ClientConfig{GatewayAddress: "0.0.0.0:0", UsePlaintextConnection: true})
+	s.Require().NoError(err)
+	defer client.Close()
+
+	// then
+	capabilities := client.(CapabilitiesProvider).Capabilities()
+	s.Empty(capabilities.GatewayVersion)
+	s.True(capabilities.Supports(FeatureStreamActivatedJobs))
+}
+
+func (s *gatewayCapabilitiesTestSuite) TestCheckGatewayVersionFailsIfGatewayIsUnreachable() {
+	// given
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	s.Require().NoError(err)
+	_ = lis.Close()
+
+	// when
+	_, err = NewClient(&ClientConfig{
+		GatewayAddress:         lis.Addr().String(),
+		UsePlaintextConnection: true,
+		CheckGatewayVersion:    true,
+	})
+
+	// then
+	s.EqualValues(codes.Unavailable, status.Code(err))
+}
+
+func (s *gatewayCapabilitiesTestSuite) TestUnsupportedFeatureIsNotSent() {
+	// given
+	gateway := &versionedGateway{version: "8.3.5"}
+	lis, grpcServer := createVersionedGatewayServer(gateway)
+	go grpcServer.Serve(lis)
+	defer grpcServer.Stop()
+
+	client, err := NewClient(&ClientConfig{
+		GatewayAddress:         lis.Addr().String(),
+		UsePlaintextConnection: true,
+		CheckGatewayVersion:    true,
+	})
+	s.Require().NoError(err)
+	defer client.Close()
+
+	// when
+	err = client.NewStreamJobsCommand().JobType("foo").Consumer(nil).Send(context.Background())
+
+	// then
+	s.ErrorIs(err, ErrUnsupportedByGateway)
+	s.EqualValues(codes.Unimplemented, status.Code(err))
+	var unsupportedErr *UnsupportedByGatewayError
+	s.Require().ErrorAs(err, &unsupportedErr)
+	s.Equal(FeatureStreamActivatedJobs, unsupportedErr.Feature)
+	s.Equal("8.3.5", unsupportedErr.GatewayVersion)
+	s.Equal("8.4.0", unsupportedErr.RequiredVersion)
+	s.Nil(unsupportedErr.Err)
+	s.EqualValues(0, atomic.LoadInt32(&gateway.streamCalls))
+}
+
+func (s *gatewayCapabilitiesTestSuite) TestUnimplementedErrorsAreTranslated() {
+	// given
+	gateway := &versionedGateway{version: "8.5.0"}
+	lis, grpcServer := createVersionedGatewayServer(gateway)
+	go grpcServer.Serve(lis)
+	defer grpcServer.Stop()
+
+	client, err := NewClient(&ClientConfig{GatewayAddress: lis.Addr().String(), UsePlaintextConnection: true})
+	s.Require().NoError(err)
+	defer client.Close()
+
+	// when
+	_, unaryErr := client.NewUpdateJobTimeoutCommand().JobKey(1).Timeout(1).Send(context.Background())
+	_, streamErr := client.NewActivateJobsCommand().JobType("foo").MaxJobsToActivate(1).Send(context.Background())
+
+	// then
+	for _, err := range []error{unaryErr, streamErr} {
+		s.ErrorIs(err, ErrUnsupportedByGateway)
+		s.EqualValues(codes.Unimplemented, status.Code(err))
+		var unsupportedErr *UnsupportedByGatewayError
+		s.Require().True(errors.As(err, &unsupportedErr))
+		s.Error(unsupportedErr.Err)
+	}
+}
+
+type versionedGateway struct {
+	pb.UnimplementedGatewayServer
+	version     string
+	streamCalls int32
+}
+
+func (g *versionedGateway) Topology(context.Context, *pb.TopologyRequest) (*pb.TopologyResponse, error) {
+	return &pb.TopologyResponse{GatewayVersion: g.version}, nil
+}
+
+func (g *versionedGateway) StreamActivatedJobs(*pb.StreamActivatedJobsRequest, pb.Gateway_StreamActivatedJobsServer) error {
+	atomic.AddInt32(&g.streamCalls, 1)
+	return status.Error(codes.Unimplemented, "expected")
+}
+
+func createVersionedGatewayServer(gateway *versionedGateway) (net.Listener, *grpc.Server) {
+	lis, _ := net.Listen("tcp", "127.0.0.1:0")
+	grpcServer := grpc.NewServer()
+	pb.RegisterGatewayServer(grpcServer, gateway)
+	return lis, grpcServer
+}
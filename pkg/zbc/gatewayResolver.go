@@ -0,0 +1,62 @@
+// Copyright © 2018 Camunda Services GmbH (info@camunda.com)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package zbc
+
+import (
+	"fmt"
+	"net"
+
+	"google.golang.org/grpc/resolver"
+)
+
+// gatewayResolverScheme is the scheme of the target used when the client is configured with a static list of gateway
+// addresses. The resolver is registered per connection, so it does not leak into other gRPC clients of the process.
+const gatewayResolverScheme = "zeebe-gateways"
+
+// gatewayResolverBuilder builds resolvers which always resolve to the same, static list of gateway addresses. The
+// load balancing policy configured on the connection then decides how calls are spread over these addresses.
+type gatewayResolverBuilder struct {
+	addresses []string
+}
+
+func (b *gatewayResolverBuilder) Build(_ resolver.Target, cc resolver.ClientConn, _ resolver.BuildOptions) (resolver.Resolver, error) {
+	addresses := make([]resolver.Address, 0, len(b.addresses))
+	for _, address := range b.addresses {
+		// the target has no host, so each address carries its own authority for the TLS handshake
+		host, _, err := net.SplitHostPort(address)
+		if err != nil {
+			return nil, fmt.Errorf("expected gateway address '%s' to be of the form host:port: %w", address, err)
+		}
+
+		addresses = append(addresses, resolver.Address{Addr: address, ServerName: host})
+	}
+
+	if err := cc.UpdateState(resolver.State{Addresses: addresses}); err != nil {
+		return nil, err
+	}
+
+	return &gatewayResolver{}, nil
+}
+
+func (*gatewayResolverBuilder) Scheme() string {
+	return gatewayResolverScheme
+}
+
+// gatewayResolver does nothing: the addresses are static and were already reported when it was built.
+type gatewayResolver struct{}
+
+func (*gatewayResolver) ResolveNow(resolver.ResolveNowOptions) {}
+
+func (*gatewayResolver) Close() {}
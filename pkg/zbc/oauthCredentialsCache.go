@@ -16,6 +16,7 @@ package zbc
 
 import (
 	"fmt"
+	"github.com/camunda-community-hub/zeebe-client-go/v8/internal/utils"
 	"github.com/mitchellh/go-homedir"
 	"golang.org/x/oauth2"
 	"gopkg.in/yaml.v3"
@@ -30,7 +31,16 @@ const OAuthCachePathEnvVar = "ZEEBE_CLIENT_CONFIG_PATH"
 const DefaultOAuthCacheFileDir = ".camunda"
 const DefaultOAuthCacheFile = "credentials"
 const oauthYamlCredentialsCachePerm = 0660
+const oauthYamlCredentialsCacheLockSuffix = ".lock"
 
+const OAuthCacheTypeEnvVar = "ZEEBE_CLIENT_CACHE_TYPE"
+const (
+	OAuthYamlCacheType      = "file"
+	OAuthEncryptedCacheType = "encrypted"
+	OAuthInMemoryCacheType  = "memory"
+)
+
+const ErrUnknownOAuthCacheType = Error("unknown OAuth credentials cache type")
 const ErrOAuthCredentialsCacheFolderIsNotDir = Error("OAuth credentials cache folder is not a directory, cannot create cache file under it")
 const ErrOAuthCredentialsCacheIsDir = Error("OAuth credentials cache must be a file, not a directory")
 
@@ -50,6 +60,9 @@ type oauthYamlCredentialsCache struct {
 	path      string
 	audiences map[string]*oauthCachedCredentials
 	lock      sync.RWMutex
+	// optional transformations applied to the file contents after marshalling and before unmarshalling
+	encode func([]byte) ([]byte, error)
+	decode func([]byte) ([]byte, error)
 }
 
 type oauthCachedCredentials struct {
@@ -57,29 +70,50 @@ type oauthCachedCredentials struct {
 }
 
 func NewOAuthYamlCredentialsCache(path string) (OAuthCredentialsCache, error) {
-	var err error
+	return newOAuthYamlCredentialsCache(&oauthYamlCredentialsCache{path: resolveOAuthCachePath(path, DefaultOauthYamlCachePath)})
+}
 
-	envCachePath := env.get(OAuthCachePathEnvVar)
-	if envCachePath != "" {
-		path = envCachePath
-	} else if path == "" {
-		path = DefaultOauthYamlCachePath
+func newOAuthYamlCredentialsCache(cache *oauthYamlCredentialsCache) (*oauthYamlCredentialsCache, error) {
+	if err := ensureOAuthCacheFileExists(cache.path); err != nil {
+		return nil, err
 	}
 
-	if err = ensureOAuthCacheFileExists(path); err != nil {
+	cache.audiences = make(map[string]*oauthCachedCredentials)
+	if err := cache.readCache(); err != nil {
 		return nil, err
 	}
 
-	cache := oauthYamlCredentialsCache{
-		path:      path,
-		audiences: make(map[string]*oauthCachedCredentials),
-	}
+	return cache, nil
+}
 
-	if err := cache.readCache(); err != nil {
-		return nil, err
+func resolveOAuthCachePath(path, defaultPath string) string {
+	if envCachePath := env.get(OAuthCachePathEnvVar); envCachePath != "" {
+		return envCachePath
+	} else if path == "" {
+		return defaultPath
 	}
 
-	return &cache, nil
+	return path
+}
+
+// NewOAuthCredentialsCacheFromEnv returns the cache selected by 'ZEEBE_CLIENT_CACHE_TYPE': a plaintext YAML file
+// ('file', the default), an encrypted file ('encrypted', keyed by 'ZEEBE_CLIENT_CACHE_KEY' or
+// 'ZEEBE_CLIENT_CACHE_KEY_PATH') or memory only ('memory')
+func NewOAuthCredentialsCacheFromEnv() (OAuthCredentialsCache, error) {
+	switch cacheType := env.get(OAuthCacheTypeEnvVar); cacheType {
+	case "", OAuthYamlCacheType:
+		return NewOAuthYamlCredentialsCache("")
+	case OAuthEncryptedCacheType:
+		key, err := getOAuthCacheKeyFromEnv()
+		if err != nil {
+			return nil, err
+		}
+		return NewOAuthEncryptedCredentialsCache("", key)
+	case OAuthInMemoryCacheType:
+		return NewOAuthInMemoryCredentialsCache(), nil
+	default:
+		return nil, fmt.Errorf("%w '%s', expected one of '%s', '%s' or '%s'", ErrUnknownOAuthCacheType, cacheType, OAuthYamlCacheType, OAuthEncryptedCacheType, OAuthInMemoryCacheType)
+	}
 }
 
 // Refresh overwrites the current in-memory contents with whatever is written in the cache file
@@ -102,44 +136,120 @@ func (cache *oauthYamlCredentialsCache) Get(audience string) *oauth2.Token {
 }
 
 // Update updates the in-memory mapping for the given audience and credentials, and flushes its contents to disk
+//
+// Credentials written to the same file by other processes for other audiences are preserved and picked up as well
 func (cache *oauthYamlCredentialsCache) Update(audience string, credentials *oauth2.Token) error {
-	cache.put(audience, credentials)
-	return cache.writeCache()
-}
-
-func (cache *oauthYamlCredentialsCache) put(audience string, credentials *oauth2.Token) {
-	cache.lock.Lock()
-	defer cache.lock.Unlock()
-	cache.audiences[audience] = &oauthCachedCredentials{
+	return cache.writeCache(audience, &oauthCachedCredentials{
 		Auth: struct{ Credentials *oauth2.Token }{Credentials: credentials},
-	}
+	})
 }
 
 // readCache will overwrite the current contents of cache.audiences, so use carefully
 func (cache *oauthYamlCredentialsCache) readCache() error {
-	cacheContents, err := os.ReadFile(cache.path)
+	var audiences map[string]*oauthCachedCredentials
+	err := cache.withFileLock(false, func() (err error) {
+		audiences, err = cache.readCacheFile()
+		return
+	})
 	if err != nil {
 		return err
 	}
 
 	cache.lock.Lock()
 	defer cache.lock.Unlock()
+	cache.audiences = audiences
 
-	err = yaml.Unmarshal(cacheContents, &cache.audiences)
-	return err
+	return nil
 }
 
-// writeCache will overwrite any contents in the current cache file, so use carefully
-func (cache *oauthYamlCredentialsCache) writeCache() error {
-	cache.lock.RLock()
-	defer cache.lock.RUnlock()
+// writeCache merges the given audience into the credentials currently stored in the cache file, so that audiences
+// written by other processes sharing the file are not lost, and atomically replaces the file with the result
+func (cache *oauthYamlCredentialsCache) writeCache(audience string, credentials *oauthCachedCredentials) error {
+	return cache.withFileLock(true, func() error {
+		audiences, err := cache.readCacheFile()
+		if err != nil {
+			return err
+		}
+		audiences[audience] = credentials
+
+		cacheContents, err := yaml.Marshal(&audiences)
+		if err != nil {
+			return err
+		}
 
-	cacheContents, err := yaml.Marshal(&cache.audiences)
+		if cache.encode != nil {
+			if cacheContents, err = cache.encode(cacheContents); err != nil {
+				return err
+			}
+		}
+
+		if err = utils.WriteFileAtomically(cache.path, cacheContents, 0600); err != nil {
+			return err
+		}
+
+		cache.lock.Lock()
+		defer cache.lock.Unlock()
+		cache.audiences = audiences
+
+		return nil
+	})
+}
+
+// readCacheFile parses the cache file; a corrupt file is reported and treated as empty, and will be replaced on the
+// next write
+func (cache *oauthYamlCredentialsCache) readCacheFile() (map[string]*oauthCachedCredentials, error) {
+	audiences := make(map[string]*oauthCachedCredentials)
+
+	cacheContents, err := os.ReadFile(cache.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return audiences, nil
+		}
+		return nil, err
+	}
+
+	if len(cacheContents) == 0 {
+		return audiences, nil
+	}
+
+	if cache.decode != nil {
+		if cacheContents, err = cache.decode(cacheContents); err != nil {
+			log.Printf("Ignoring OAuth credentials cache %s which cannot be decoded: %s", cache.path, err.Error())
+			return audiences, nil
+		}
+	}
+
+	if err = yaml.Unmarshal(cacheContents, &audiences); err != nil {
+		log.Printf("Ignoring corrupt OAuth credentials cache %s: %s", cache.path, err.Error())
+		return make(map[string]*oauthCachedCredentials), nil
+	}
+
+	for audience, credentials := range audiences {
+		if credentials == nil || credentials.Auth.Credentials == nil {
+			delete(audiences, audience)
+		}
+	}
+
+	return audiences, nil
+}
+
+// withFileLock runs the given function while holding an advisory lock on the cache's lock file, which serializes
+// access with other processes sharing the same cache file
+func (cache *oauthYamlCredentialsCache) withFileLock(exclusive bool, fn func() error) error {
+	lock, err := os.OpenFile(cache.path+oauthYamlCredentialsCacheLockSuffix, os.O_RDWR|os.O_CREATE, oauthYamlCredentialsCachePerm)
 	if err != nil {
 		return err
 	}
+	defer lock.Close()
+
+	if err = lockFile(lock, exclusive); err != nil {
+		return fmt.Errorf("failed to lock OAuth credentials cache %s: %w", cache.path, err)
+	}
+	defer func() {
+		_ = unlockFile(lock)
+	}()
 
-	return os.WriteFile(cache.path, cacheContents, 0600)
+	return fn()
 }
 
 func getDefaultOAuthYamlCredentialsCacheRelativePath() string {
@@ -15,11 +15,13 @@
 package zbc
 
 import (
+	"fmt"
 	"github.com/stretchr/testify/suite"
 	"golang.org/x/oauth2"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 )
@@ -131,6 +133,183 @@ func (s *oauthCredsCacheTestSuite) TestOAuthYamlCredentialsCachePathFromEnvironm
 	s.Empty(cache.audiences)
 }
 
+func (s *oauthCredsCacheTestSuite) TestUpdateMergesAudiencesWrittenByOthers() {
+	// given
+	cachePath := copyCredentialsCacheGoldenFileToTempFile()
+	cache, err := NewOAuthYamlCredentialsCache(cachePath)
+	s.Require().NoError(err)
+	other, err := NewOAuthYamlCredentialsCache(cachePath)
+	s.Require().NoError(err)
+
+	capybara := &oauth2.Token{AccessToken: "capybara", Expiry: wombat.Expiry, TokenType: "Bearer"}
+	s.Require().NoError(other.Update("capybara.cloud.camunda.io", capybara))
+
+	// when
+	quokka := &oauth2.Token{AccessToken: "quokka", Expiry: wombat.Expiry, TokenType: "Bearer"}
+	err = cache.Update("quokka.cloud.camunda.io", quokka)
+
+	// then
+	s.NoError(err)
+	s.EqualValues(capybara, cache.Get("capybara.cloud.camunda.io"))
+
+	s.NoError(other.Refresh())
+	s.EqualValues(wombat, other.Get(wombatAudience))
+	s.EqualValues(aardvark, other.Get(aardvarkAudience))
+	s.EqualValues(capybara, other.Get("capybara.cloud.camunda.io"))
+	s.EqualValues(quokka, other.Get("quokka.cloud.camunda.io"))
+}
+
+func (s *oauthCredsCacheTestSuite) TestRecoverFromCorruptCacheFile() {
+	// given
+	cachePath := copyCredentialsCacheGoldenFileToTempFile()
+	s.Require().NoError(os.WriteFile(cachePath, []byte("wombat: [this is: not"), 0600))
+
+	// when
+	cache, err := NewOAuthYamlCredentialsCache(cachePath)
+
+	// then
+	s.Require().NoError(err)
+	s.Nil(cache.Get(wombatAudience))
+
+	s.NoError(cache.Update(wombatAudience, wombat))
+	cacheCopy, err := NewOAuthYamlCredentialsCache(cachePath)
+	s.NoError(err)
+	s.EqualValues(wombat, cacheCopy.Get(wombatAudience))
+}
+
+func (s *oauthCredsCacheTestSuite) TestConcurrentUpdatesDoNotLoseAudiences() {
+	// given
+	cachePath := copyCredentialsCacheGoldenFileToTempFile()
+	const writers = 10
+
+	// when
+	var wg sync.WaitGroup
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			// each writer uses its own cache instance, as separate processes would
+			cache, err := NewOAuthYamlCredentialsCache(cachePath)
+			s.NoError(err)
+			s.NoError(cache.Update(fmt.Sprintf("audience-%d", i), wombat))
+		}(i)
+	}
+	wg.Wait()
+
+	// then
+	cache, err := NewOAuthYamlCredentialsCache(cachePath)
+	s.Require().NoError(err)
+	for i := 0; i < writers; i++ {
+		s.EqualValues(wombat, cache.Get(fmt.Sprintf("audience-%d", i)))
+	}
+	s.EqualValues(aardvark, cache.Get(aardvarkAudience))
+
+	leftovers, err := filepath.Glob(cachePath + ".*.tmp")
+	s.NoError(err)
+	s.Empty(leftovers)
+}
+
+func (s *oauthCredsCacheTestSuite) TestEncryptedCacheRoundTrip() {
+	// given
+	cachePath := filepath.Join(s.T().TempDir(), "credentials.enc")
+	cache, err := NewOAuthEncryptedCredentialsCache(cachePath, []byte("secret"))
+	s.Require().NoError(err)
+
+	// when
+	s.Require().NoError(cache.Update(wombatAudience, wombat))
+
+	// then
+	contents, err := os.ReadFile(cachePath)
+	s.NoError(err)
+	s.NotContains(string(contents), wombat.AccessToken)
+	s.NotContains(string(contents), wombatAudience)
+
+	cacheCopy, err := NewOAuthEncryptedCredentialsCache(cachePath, []byte("secret"))
+	s.NoError(err)
+	s.EqualValues(wombat, cacheCopy.Get(wombatAudience))
+}
+
+func (s *oauthCredsCacheTestSuite) TestEncryptedCacheUsesRandomSaltPerFile() {
+	// given
+	firstPath := filepath.Join(s.T().TempDir(), "credentials.enc")
+	secondPath := filepath.Join(s.T().TempDir(), "credentials.enc")
+	first, err := NewOAuthEncryptedCredentialsCache(firstPath, []byte("secret"))
+	s.Require().NoError(err)
+	second, err := NewOAuthEncryptedCredentialsCache(secondPath, []byte("secret"))
+	s.Require().NoError(err)
+
+	// when
+	s.Require().NoError(first.Update(wombatAudience, wombat))
+	s.Require().NoError(second.Update(wombatAudience, wombat))
+
+	// then
+	firstContents, err := os.ReadFile(firstPath)
+	s.Require().NoError(err)
+	secondContents, err := os.ReadFile(secondPath)
+	s.Require().NoError(err)
+	s.NotEqual(firstContents[:oauthCacheSaltLength], secondContents[:oauthCacheSaltLength])
+}
+
+func (s *oauthCredsCacheTestSuite) TestEncryptedCacheIgnoresFileWithOtherKey() {
+	// given
+	cachePath := filepath.Join(s.T().TempDir(), "credentials.enc")
+	cache, err := NewOAuthEncryptedCredentialsCache(cachePath, []byte("secret"))
+	s.Require().NoError(err)
+	s.Require().NoError(cache.Update(wombatAudience, wombat))
+
+	// when
+	otherCache, err := NewOAuthEncryptedCredentialsCache(cachePath, []byte("rotated"))
+
+	// then
+	s.Require().NoError(err)
+	s.Nil(otherCache.Get(wombatAudience))
+	s.NoError(otherCache.Update(aardvarkAudience, aardvark))
+	s.NoError(otherCache.Refresh())
+	s.EqualValues(aardvark, otherCache.Get(aardvarkAudience))
+}
+
+func (s *oauthCredsCacheTestSuite) TestEncryptedCacheRequiresKey() {
+	_, err := NewOAuthEncryptedCredentialsCache(filepath.Join(s.T().TempDir(), "credentials.enc"), nil)
+	s.ErrorIs(err, ErrOAuthCacheKeyMissing)
+}
+
+func (s *oauthCredsCacheTestSuite) TestInMemoryCache() {
+	cache := NewOAuthInMemoryCredentialsCache()
+
+	s.Nil(cache.Get(wombatAudience))
+	s.NoError(cache.Update(wombatAudience, wombat))
+	s.NoError(cache.Refresh())
+	s.EqualValues(wombat, cache.Get(wombatAudience))
+}
+
+func (s *oauthCredsCacheTestSuite) TestCacheTypeFromEnvironment() {
+	keyPath := filepath.Join(s.T().TempDir(), "key")
+	s.Require().NoError(os.WriteFile(keyPath, []byte("secret\n"), 0600))
+	cachePath := filepath.Join(s.T().TempDir(), "credentials.enc")
+
+	env.set(OAuthCacheTypeEnvVar, OAuthInMemoryCacheType)
+	cache, err := NewOAuthCredentialsCacheFromEnv()
+	s.NoError(err)
+	s.IsType(&oauthInMemoryCredentialsCache{}, cache)
+
+	env.set(OAuthCacheTypeEnvVar, OAuthEncryptedCacheType)
+	_, err = NewOAuthCredentialsCacheFromEnv()
+	s.ErrorIs(err, ErrOAuthCacheKeyMissing)
+
+	env.set(OAuthCacheKeyPathEnvVar, keyPath)
+	env.set(OAuthCachePathEnvVar, cachePath)
+	cache, err = NewOAuthCredentialsCacheFromEnv()
+	s.Require().NoError(err)
+	s.NoError(cache.Update(wombatAudience, wombat))
+	cacheCopy, err := NewOAuthEncryptedCredentialsCache(cachePath, []byte("secret"))
+	s.NoError(err)
+	s.EqualValues(wombat, cacheCopy.Get(wombatAudience))
+
+	env.set(OAuthCacheTypeEnvVar, "keyring")
+	_, err = NewOAuthCredentialsCacheFromEnv()
+	s.ErrorIs(err, ErrUnknownOAuthCacheType)
+}
+
 func copyCredentialsCacheGoldenFileToTempFile() string {
 	cache, err := os.ReadFile("testdata/credentialsCache.yml")
 	if err != nil {
@@ -17,6 +17,7 @@ package zbc
 import (
 	"context"
 	"fmt"
+	"github.com/camunda-community-hub/zeebe-client-go/v8/pkg/worker"
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/go-ozzo/ozzo-validation/v4/is"
 	"golang.org/x/oauth2"
@@ -25,7 +26,9 @@ import (
 	"google.golang.org/grpc/status"
 	"log"
 	"net/http"
+	"os"
 	"strconv"
+	"sync"
 	"time"
 )
 
@@ -44,6 +47,16 @@ const OAuthTokenScopeEnvVar = "ZEEBE_TOKEN_SCOPE"
 //nolint:revive
 const OAuthAuthorizationUrlEnvVar = "ZEEBE_AUTHORIZATION_SERVER_URL"
 const OAuthRequestTimeoutEnvVar = "ZEEBE_AUTH_REQUEST_TIMEOUT"
+const OAuthRefreshSkewEnvVar = "ZEEBE_TOKEN_REFRESH_SKEW"
+
+// #nosec 101
+const OAuthClientAssertionKeyPathEnvVar = "ZEEBE_CLIENT_ASSERTION_KEY_PATH"
+
+//nolint:revive
+const OAuthClientAssertionKeyIdEnvVar = "ZEEBE_CLIENT_ASSERTION_KEY_ID"
+
+// #nosec 101
+const OAuthSubjectTokenPathEnvVar = "ZEEBE_SUBJECT_TOKEN_PATH"
 
 // OAuthDefaultAuthzURL points to the expected default URL for this credentials provider, the Camunda Cloud endpoint.
 const OAuthDefaultAuthzURL = "https://login.cloud.camunda.io/oauth/token/"
@@ -51,16 +64,53 @@ const OAuthDefaultAuthzURL = "https://login.cloud.camunda.io/oauth/token/"
 // OAuthDefaultRequestTimeout is the default timeout for OAuth requests
 const OAuthDefaultRequestTimeout = 10 * time.Second
 
+// OAuthDefaultRefreshSkew is how long before its expiry an access token is refreshed by default
+const OAuthDefaultRefreshSkew = 30 * time.Second
+
+// oauthMaxRefreshSkewFraction bounds the refresh skew to a fraction of the lifetime of the access token, so that
+// short-lived tokens are not refreshed as soon as they are received
+const oauthMaxRefreshSkewFraction = 0.5
+
+const (
+	oauthRefreshMinRetryDelay = 500 * time.Millisecond
+	oauthRefreshMaxRetryDelay = 30 * time.Second
+	oauthRefreshJitterFactor  = 0.25
+)
+
 // OAuthCredentialsProvider is a built-in CredentialsProvider that contains credentials obtained from an OAuth
 // authorization server, including a token prefix and an access token. Using these values it sets the 'Authorization'
 // header of each gRPC call.
 type OAuthCredentialsProvider struct {
 	Audience    string
+	TokenSource OAuthTokenSource
+	// Deprecated: only set when the client secret grant is used, in which case it is also the TokenSource. Use
+	// TokenSource instead.
 	TokenConfig *clientcredentials.Config
 	Cache       OAuthCredentialsCache
 
-	token   *oauth2.Token
-	timeout time.Duration
+	token *oauth2.Token
+	// tokenReceivedAt is when the current token was received or read from the cache, to estimate its lifetime
+	tokenReceivedAt time.Time
+	timeout         time.Duration
+	refreshSkew     time.Duration
+	refreshBackoff  worker.BackoffSupplier
+
+	lock sync.Mutex
+	// the request to the authorization server in flight, if any
+	refresh *tokenRefresh
+	// whether a background refresh is running, including its retries
+	refreshingInBackground bool
+	// closed is done once the provider is closed, which stops background refreshes
+	closed    context.Context
+	close     context.CancelFunc
+	closeInit sync.Once
+}
+
+// tokenRefresh is a single request for a new access token, whose result is shared by all callers waiting for it.
+type tokenRefresh struct {
+	done    chan struct{}
+	updated bool
+	err     error
 }
 
 // OAuthProviderConfig configures an OAuthCredentialsProvider, containing the required data to request an access token
@@ -78,10 +128,31 @@ type OAuthProviderConfig struct {
 	// the environment variable 'ZEEBE_AUTHORIZATION_SERVER_URL'.
 	AuthorizationServerURL string
 	// Cache to read/write credentials from; if none given, defaults to an oauthYamlCredentialsCache instance with the
-	// path '$HOME/.camunda/credentials' as default (can be overridden by 'ZEEBE_CLIENT_CONFIG_PATH')
+	// path '$HOME/.camunda/credentials' as default (can be overridden by 'ZEEBE_CLIENT_CONFIG_PATH'). The environment
+	// variable 'ZEEBE_CLIENT_CACHE_TYPE' selects an encrypted or in-memory cache instead.
 	Cache OAuthCredentialsCache
 	// Timeout is the maximum duration of an OAuth request. The default value is 10 seconds
 	Timeout time.Duration
+	// RefreshSkew is how long before its expiry the access token is refreshed in the background, so that calls never
+	// have to wait for a new token or fail with an expired one. If the authorization server can't be reached, the
+	// refresh is retried with a jittered backoff for as long as the current token is valid. The default value is 30
+	// seconds; a negative value disables refreshing ahead of expiry. It is bounded by half the lifetime of the access
+	// token. Can be overridden with the environment variable 'ZEEBE_TOKEN_REFRESH_SKEW', in milliseconds.
+	RefreshSkew time.Duration
+
+	// The path to a PEM encoded private key. If set, the client authenticates with a JWT signed by this key instead of
+	// the client secret ('private_key_jwt', see RFC 7523). Can be overridden with the environment variable
+	// 'ZEEBE_CLIENT_ASSERTION_KEY_PATH'.
+	ClientAssertionKeyPath string
+	// The optional identifier of the client assertion key, sent as the 'kid' header of the JWT. Can be overridden with
+	// the environment variable 'ZEEBE_CLIENT_ASSERTION_KEY_ID'.
+	ClientAssertionKeyID string
+	// The path to a token which is exchanged for an access token (see RFC 8693), e.g. a projected Kubernetes service
+	// account token. The file is read again on every exchange. Takes precedence over the client secret and client
+	// assertion. Can be overridden with the environment variable 'ZEEBE_SUBJECT_TOKEN_PATH'.
+	SubjectTokenPath string
+	// TokenSource to request access tokens with. If set, it takes precedence over all the grant specific settings above.
+	TokenSource OAuthTokenSource
 }
 
 // ApplyCredentials takes a map of headers as input and adds an access token prefixed by a token type to the 'Authorization'
@@ -117,71 +188,283 @@ func NewOAuthCredentialsProvider(config *OAuthProviderConfig) (*OAuthCredentials
 	if err := applyCredentialEnvOverrides(config); err != nil {
 		return nil, err
 	}
-	applyCredentialDefaults(config)
+	if err := applyCredentialDefaults(config); err != nil {
+		return nil, err
+	}
 
-	if err := validation.Validate(config.AuthorizationServerURL, is.URL); err != nil {
-		return nil, fmt.Errorf("expected to find valid authz server URL '%s': %w", config.AuthorizationServerURL, err)
-	} else if err := validation.Validate(config.ClientID, validation.Required); err != nil {
-		return nil, fmt.Errorf("expected to find non-empty client id")
-	} else if err := validation.Validate(config.ClientSecret, validation.Required); err != nil {
-		return nil, fmt.Errorf("expected to find non-empty client secret")
-	} else if err := validation.Validate(config.Audience, validation.Required); err != nil {
+	if err := validation.Validate(config.Audience, validation.Required); err != nil {
 		return nil, fmt.Errorf("expected to find non-empty audience")
 	}
 
+	closed, closeProvider := context.WithCancel(context.Background())
 	provider := OAuthCredentialsProvider{
-		TokenConfig: &clientcredentials.Config{
-			ClientID:       config.ClientID,
-			ClientSecret:   config.ClientSecret,
-			EndpointParams: map[string][]string{"audience": {config.Audience}},
-			TokenURL:       config.AuthorizationServerURL,
-			AuthStyle:      oauth2.AuthStyleInParams,
-		},
-		Audience: config.Audience,
-		Cache:    config.Cache,
-		timeout:  config.Timeout,
+		closed:      closed,
+		close:       closeProvider,
+		TokenSource: config.TokenSource,
+		Audience:    config.Audience,
+		Cache:       config.Cache,
+		timeout:     config.Timeout,
+		refreshSkew: config.RefreshSkew,
+		refreshBackoff: worker.NewExponentialBackoffBuilder().
+			MinDelay(oauthRefreshMinRetryDelay).
+			MaxDelay(oauthRefreshMaxRetryDelay).
+			JitterFactor(oauthRefreshJitterFactor).
+			Build(),
 	}
 
-	if config.Scope != "" {
-		provider.TokenConfig.Scopes = []string{config.Scope}
+	if provider.TokenSource == nil {
+		tokenSource, tokenConfig, err := newTokenSource(config)
+		if err != nil {
+			return nil, err
+		}
+
+		provider.TokenSource = tokenSource
+		provider.TokenConfig = tokenConfig
 	}
 
 	return &provider, nil
 }
 
+// newTokenSource creates the token source for the grant configured, in order of precedence: token exchange, client
+// assertion and finally client secret. For the latter, the underlying client credentials config is returned as well.
+func newTokenSource(config *OAuthProviderConfig) (OAuthTokenSource, *clientcredentials.Config, error) {
+	if err := validation.Validate(config.AuthorizationServerURL, is.URL); err != nil {
+		return nil, nil, fmt.Errorf("expected to find valid authz server URL '%s': %w", config.AuthorizationServerURL, err)
+	}
+
+	var scopes []string
+	if config.Scope != "" {
+		scopes = []string{config.Scope}
+	}
+
+	if config.SubjectTokenPath != "" {
+		return NewTokenExchangeTokenSource(&TokenExchangeConfig{
+			ClientID:         config.ClientID,
+			ClientSecret:     config.ClientSecret,
+			TokenURL:         config.AuthorizationServerURL,
+			Audience:         config.Audience,
+			Scopes:           scopes,
+			SubjectTokenPath: config.SubjectTokenPath,
+		}), nil, nil
+	}
+
+	if err := validation.Validate(config.ClientID, validation.Required); err != nil {
+		return nil, nil, fmt.Errorf("expected to find non-empty client id")
+	}
+
+	if config.ClientAssertionKeyPath != "" {
+		privateKey, err := os.ReadFile(config.ClientAssertionKeyPath)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read client assertion key: %w", err)
+		}
+
+		tokenSource, err := NewJWTClientAssertionTokenSource(&JWTClientAssertionConfig{
+			ClientID:   config.ClientID,
+			TokenURL:   config.AuthorizationServerURL,
+			Audience:   config.Audience,
+			Scopes:     scopes,
+			PrivateKey: privateKey,
+			KeyID:      config.ClientAssertionKeyID,
+		})
+		return tokenSource, nil, err
+	}
+
+	if err := validation.Validate(config.ClientSecret, validation.Required); err != nil {
+		return nil, nil, fmt.Errorf("expected to find non-empty client secret")
+	}
+
+	tokenConfig := newClientCredentialsConfig(config)
+	return tokenConfig, tokenConfig, nil
+}
+
 func (p *OAuthCredentialsProvider) getCredentials(ctx context.Context) (*oauth2.Token, error) {
-	if p.token == nil || !p.token.Valid() {
+	token := p.currentToken()
+
+	if token == nil || !token.Valid() {
 		credentials := p.getCachedToken()
 
 		if credentials != nil && credentials.Valid() {
-			p.token = credentials
-			return credentials, nil
-		}
+			p.setToken(credentials)
+			token = credentials
+		} else {
+			if _, err := p.updateCredentials(ctx); err != nil {
+				return nil, err
+			}
 
-		if _, err := p.updateCredentials(ctx); err != nil {
-			return nil, err
+			return p.currentToken(), nil
 		}
 	}
-	return p.token, nil
+
+	if p.expiresSoon(token) {
+		p.refreshInBackground()
+	}
+
+	return token, nil
 }
 
+// updateCredentials requests a new access token, making sure only one request to the authorization server is in
+// flight at a time: concurrent callers wait for and share the result of the request in flight.
 func (p *OAuthCredentialsProvider) updateCredentials(ctx context.Context) (bool, error) {
+	p.lock.Lock()
+	refresh := p.refresh
+	if refresh == nil {
+		refresh = &tokenRefresh{done: make(chan struct{})}
+		p.refresh = refresh
+
+		// the request is shared, so it must not be cancelled along with the caller which happened to start it
+		go p.requestToken(context.WithoutCancel(ctx), refresh)
+	}
+	p.lock.Unlock()
+
+	select {
+	case <-refresh.done:
+		return refresh.updated, refresh.err
+	case <-ctx.Done():
+		return false, ctx.Err()
+	}
+}
+
+func (p *OAuthCredentialsProvider) requestToken(ctx context.Context, refresh *tokenRefresh) {
+	defer func() {
+		p.lock.Lock()
+		p.refresh = nil
+		p.lock.Unlock()
+		close(refresh.done)
+	}()
+
 	ctx, cancel := context.WithTimeout(ctx, p.timeout)
 	defer cancel()
 
 	client := &http.Client{Transport: &userAgentRT{r: http.DefaultTransport}}
 	ctx = context.WithValue(ctx, oauth2.HTTPClient, client)
 
-	token, err := p.TokenConfig.Token(ctx)
+	tokenSource := p.TokenSource
+	if tokenSource == nil {
+		tokenSource = p.TokenConfig
+	}
+
+	token, err := tokenSource.Token(ctx)
 	if err != nil {
-		return false, fmt.Errorf("failed to obtain access token: %w", err)
-	} else if p.token == nil || !p.token.Valid() || p.token.AccessToken != token.AccessToken {
+		refresh.err = fmt.Errorf("failed to obtain access token: %w", err)
+		return
+	}
+
+	p.lock.Lock()
+	current := p.token
+	refresh.updated = current == nil || !current.Valid() || current.AccessToken != token.AccessToken
+	if refresh.updated {
 		p.token = token
+		p.tokenReceivedAt = time.Now()
+	}
+	p.lock.Unlock()
+
+	if refresh.updated {
 		p.updateCache(token)
-		return true, nil
 	}
+}
+
+// refreshInBackground requests a new access token without blocking the caller. Failed requests are retried with a
+// jittered backoff for as long as the current token is still valid; after that, callers request one themselves.
+func (p *OAuthCredentialsProvider) refreshInBackground() {
+	p.lock.Lock()
+	defer p.lock.Unlock()
 
-	return false, nil
+	if p.refreshingInBackground {
+		return
+	}
+	p.refreshingInBackground = true
+
+	go func() {
+		defer func() {
+			p.lock.Lock()
+			p.refreshingInBackground = false
+			p.lock.Unlock()
+		}()
+
+		closed := p.closedContext()
+		retryDelay := time.Duration(0)
+		for {
+			_, err := p.updateCredentials(closed)
+			if err == nil || closed.Err() != nil {
+				return
+			}
+
+			token := p.currentToken()
+			if token == nil || !token.Valid() {
+				log.Printf("Failed to refresh access token ahead of its expiry: %s", err.Error())
+				return
+			}
+
+			retryDelay = p.refreshBackoff.SupplyRetryDelay(retryDelay)
+			if remaining := time.Until(token.Expiry); retryDelay > remaining {
+				retryDelay = remaining
+			}
+
+			log.Printf("Failed to refresh access token ahead of its expiry, retrying in %s: %s", retryDelay, err.Error())
+			timer := time.NewTimer(retryDelay)
+			select {
+			case <-timer.C:
+			case <-closed.Done():
+				timer.Stop()
+				return
+			}
+		}
+	}()
+}
+
+// Close stops refreshing the access token in the background. The provider still applies credentials afterwards,
+// requesting new tokens only when the current one expired.
+func (p *OAuthCredentialsProvider) Close() error {
+	p.closedContext()
+	p.close()
+	return nil
+}
+
+// closedContext returns the context which is done once the provider is closed, creating it for providers which were
+// not created by NewOAuthCredentialsProvider
+func (p *OAuthCredentialsProvider) closedContext() context.Context {
+	p.closeInit.Do(func() {
+		if p.closed == nil {
+			p.closed, p.close = context.WithCancel(context.Background())
+		}
+	})
+	return p.closed
+}
+
+// expiresSoon returns true if the token expires within the refresh skew, which is bounded by a fraction of the
+// lifetime of the token
+func (p *OAuthCredentialsProvider) expiresSoon(token *oauth2.Token) bool {
+	skew := p.refreshSkew
+	if skew < 0 || token.Expiry.IsZero() || p.closedContext().Err() != nil {
+		return false
+	} else if skew == 0 {
+		skew = OAuthDefaultRefreshSkew
+	}
+
+	p.lock.Lock()
+	receivedAt := p.tokenReceivedAt
+	p.lock.Unlock()
+
+	if maxSkew := time.Duration(float64(token.Expiry.Sub(receivedAt)) * oauthMaxRefreshSkewFraction); skew > maxSkew {
+		skew = maxSkew
+	}
+
+	return time.Until(token.Expiry) < skew
+}
+
+func (p *OAuthCredentialsProvider) currentToken() *oauth2.Token {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	return p.token
+}
+
+func (p *OAuthCredentialsProvider) setToken(token *oauth2.Token) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.token = token
+	p.tokenReceivedAt = time.Now()
 }
 
 type userAgentRT struct {
@@ -226,6 +509,22 @@ func applyCredentialEnvOverrides(config *OAuthProviderConfig) error {
 	if envAuthzServerURL := env.get(OAuthAuthorizationUrlEnvVar); envAuthzServerURL != "" {
 		config.AuthorizationServerURL = envAuthzServerURL
 	}
+	if envKeyPath := env.get(OAuthClientAssertionKeyPathEnvVar); envKeyPath != "" {
+		config.ClientAssertionKeyPath = envKeyPath
+	}
+	if envKeyID := env.get(OAuthClientAssertionKeyIdEnvVar); envKeyID != "" {
+		config.ClientAssertionKeyID = envKeyID
+	}
+	if envSubjectTokenPath := env.get(OAuthSubjectTokenPathEnvVar); envSubjectTokenPath != "" {
+		config.SubjectTokenPath = envSubjectTokenPath
+	}
+	if envRefreshSkew := env.get(OAuthRefreshSkewEnvVar); envRefreshSkew != "" {
+		skew, err := strconv.ParseInt(envRefreshSkew, 10, 64)
+		if err != nil {
+			return fmt.Errorf("could not parse value of %s, should be an amount of milliseconds: %w", OAuthRefreshSkewEnvVar, err)
+		}
+		config.RefreshSkew = time.Duration(skew) * time.Millisecond
+	}
 	if envOAuthReqTimeout := env.get(OAuthRequestTimeoutEnvVar); envOAuthReqTimeout != "" {
 		timeout, err := strconv.ParseUint(envOAuthReqTimeout, 10, 64)
 		if err != nil {
@@ -237,21 +536,26 @@ func applyCredentialEnvOverrides(config *OAuthProviderConfig) error {
 	return nil
 }
 
-func applyCredentialDefaults(config *OAuthProviderConfig) {
+func applyCredentialDefaults(config *OAuthProviderConfig) error {
 	if config.AuthorizationServerURL == "" {
 		config.AuthorizationServerURL = OAuthDefaultAuthzURL
 	}
 
 	if config.Cache == nil {
-		cache, err := NewOAuthYamlCredentialsCache("")
-		if err != nil {
-			log.Printf("Failed to create OAuth YAML token cache with default path: %s", err.Error())
-		} else {
-			config.Cache = cache
+		cache, err := NewOAuthCredentialsCacheFromEnv()
+		if err != nil && env.get(OAuthCacheTypeEnvVar) != "" {
+			// a cache explicitly chosen, e.g. an encrypted one, must not be silently replaced
+			return fmt.Errorf("failed to create OAuth token cache: %w", err)
+		} else if err != nil {
+			log.Printf("Failed to create OAuth token cache, falling back to an in-memory cache: %s", err.Error())
+			cache = NewOAuthInMemoryCredentialsCache()
 		}
+		config.Cache = cache
 	}
 
 	if config.Timeout <= time.Duration(0) {
 		config.Timeout = OAuthDefaultRequestTimeout
 	}
+
+	return nil
 }
@@ -15,6 +15,7 @@
 package zbc
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"net"
@@ -24,9 +25,11 @@ import (
 	"regexp"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/camunda-community-hub/zeebe-client-go/v8/internal/utils"
 	"github.com/camunda-community-hub/zeebe-client-go/v8/pkg/entities"
 	"github.com/camunda-community-hub/zeebe-client-go/v8/pkg/worker"
 	"github.com/stretchr/testify/require"
@@ -93,6 +96,21 @@ func (s *oauthCredsProviderTestSuite) TestOAuthCredentialsProvider() {
 	s.Equal("Bearer "+accessToken, interceptor.authHeader)
 }
 
+func (s *oauthCredsProviderTestSuite) TestOAuthCredentialsProviderFailsWithMisconfiguredCache() {
+	// given
+	env.set(OAuthCacheTypeEnvVar, OAuthEncryptedCacheType)
+
+	// when
+	_, err := NewOAuthCredentialsProvider(&OAuthProviderConfig{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		Audience:     audience,
+	})
+
+	// then
+	s.ErrorIs(err, ErrOAuthCacheKeyMissing)
+}
+
 func (s *oauthCredsProviderTestSuite) TestOAuthCredentialsProviderWithScope() {
 	// given
 	truncateDefaultOAuthYamlCacheFile()
@@ -808,6 +826,206 @@ func (s *oauthCredsProviderTestSuite) TestJobActivateRetry() {
 	s.NotEmpty(jobs)
 }
 
+func (s *oauthCredsProviderTestSuite) TestRefreshTokenAheadOfExpiry() {
+	// given
+	truncateDefaultOAuthYamlCacheFile()
+	var calls int32
+	provider, err := NewOAuthCredentialsProvider(&OAuthProviderConfig{
+		Audience:    audience,
+		RefreshSkew: time.Minute,
+		TokenSource: OAuthTokenSourceFunc(func(context.Context) (*oauth2.Token, error) {
+			call := atomic.AddInt32(&calls, 1)
+			return &oauth2.Token{AccessToken: fmt.Sprintf("token-%d", call), Expiry: time.Now().Add(30 * time.Second)}, nil
+		}),
+	})
+	s.Require().NoError(err)
+	defer provider.Close()
+
+	headers := make(map[string]string)
+	s.Require().NoError(provider.ApplyCredentials(context.Background(), headers))
+	s.Equal("Bearer token-1", headers["Authorization"])
+
+	// when - the token is still valid but expires within the skew, having been received 90 seconds before its expiry
+	provider.lock.Lock()
+	provider.tokenReceivedAt = provider.token.Expiry.Add(-90 * time.Second)
+	provider.lock.Unlock()
+	s.Require().NoError(provider.ApplyCredentials(context.Background(), headers))
+
+	// then - the current token is used while a new one is requested in the background
+	s.Equal("Bearer token-1", headers["Authorization"])
+	s.Eventually(func() bool {
+		return provider.currentToken().AccessToken != "token-1"
+	}, utils.DefaultTestTimeout, 10*time.Millisecond)
+	s.Require().NoError(provider.ApplyCredentials(context.Background(), headers))
+	s.NotEqual("Bearer token-1", headers["Authorization"])
+}
+
+func (s *oauthCredsProviderTestSuite) TestRefreshSkewIsBoundedByTokenLifetime() {
+	// given
+	truncateDefaultOAuthYamlCacheFile()
+	var calls int32
+	provider, err := NewOAuthCredentialsProvider(&OAuthProviderConfig{
+		Audience:    audience,
+		RefreshSkew: time.Minute,
+		TokenSource: OAuthTokenSourceFunc(func(context.Context) (*oauth2.Token, error) {
+			atomic.AddInt32(&calls, 1)
+			return &oauth2.Token{AccessToken: accessToken, Expiry: time.Now().Add(30 * time.Second)}, nil
+		}),
+	})
+	s.Require().NoError(err)
+	defer provider.Close()
+
+	// when - the tokens live shorter than the skew
+	headers := make(map[string]string)
+	for i := 0; i < 5; i++ {
+		s.Require().NoError(provider.ApplyCredentials(context.Background(), headers))
+	}
+
+	// then
+	s.Never(func() bool {
+		return atomic.LoadInt32(&calls) > 1
+	}, 100*time.Millisecond, 10*time.Millisecond)
+}
+
+func (s *oauthCredsProviderTestSuite) TestZeroValueProviderCanBeClosed() {
+	provider := &OAuthCredentialsProvider{}
+	token := &oauth2.Token{AccessToken: accessToken, Expiry: time.Now().Add(time.Hour)}
+
+	s.NotPanics(func() { provider.expiresSoon(token) })
+	s.NoError(provider.Close())
+	s.False(provider.expiresSoon(token))
+}
+
+func (s *oauthCredsProviderTestSuite) TestCloseStopsRefreshingInBackground() {
+	// given
+	truncateDefaultOAuthYamlCacheFile()
+	var calls int32
+	provider, err := NewOAuthCredentialsProvider(&OAuthProviderConfig{
+		Audience:    audience,
+		RefreshSkew: time.Minute,
+		TokenSource: OAuthTokenSourceFunc(func(context.Context) (*oauth2.Token, error) {
+			atomic.AddInt32(&calls, 1)
+			return &oauth2.Token{AccessToken: accessToken, Expiry: time.Now().Add(30 * time.Second)}, nil
+		}),
+	})
+	s.Require().NoError(err)
+	headers := make(map[string]string)
+	s.Require().NoError(provider.ApplyCredentials(context.Background(), headers))
+	provider.lock.Lock()
+	provider.tokenReceivedAt = provider.token.Expiry.Add(-90 * time.Second)
+	provider.lock.Unlock()
+
+	// when
+	s.Require().NoError(provider.Close())
+	s.Require().NoError(provider.ApplyCredentials(context.Background(), headers))
+
+	// then
+	s.Never(func() bool {
+		return atomic.LoadInt32(&calls) > 1
+	}, 100*time.Millisecond, 10*time.Millisecond)
+}
+
+func (s *oauthCredsProviderTestSuite) TestNoRefreshAheadOfExpiryWithNegativeSkew() {
+	// given
+	truncateDefaultOAuthYamlCacheFile()
+	var calls int32
+	provider, err := NewOAuthCredentialsProvider(&OAuthProviderConfig{
+		Audience:    audience,
+		RefreshSkew: -1,
+		TokenSource: OAuthTokenSourceFunc(func(context.Context) (*oauth2.Token, error) {
+			atomic.AddInt32(&calls, 1)
+			return &oauth2.Token{AccessToken: accessToken, Expiry: time.Now().Add(30 * time.Second)}, nil
+		}),
+	})
+	s.Require().NoError(err)
+
+	// when
+	headers := make(map[string]string)
+	s.Require().NoError(provider.ApplyCredentials(context.Background(), headers))
+	s.Require().NoError(provider.ApplyCredentials(context.Background(), headers))
+
+	// then
+	s.Never(func() bool {
+		return atomic.LoadInt32(&calls) > 1
+	}, 100*time.Millisecond, 10*time.Millisecond)
+}
+
+func (s *oauthCredsProviderTestSuite) TestConcurrentRefreshesAreDeduplicated() {
+	// given
+	truncateDefaultOAuthYamlCacheFile()
+	var calls int32
+	release := make(chan struct{})
+	provider, err := NewOAuthCredentialsProvider(&OAuthProviderConfig{
+		Audience: audience,
+		TokenSource: OAuthTokenSourceFunc(func(context.Context) (*oauth2.Token, error) {
+			atomic.AddInt32(&calls, 1)
+			<-release
+			return &oauth2.Token{AccessToken: accessToken, Expiry: time.Now().Add(time.Hour)}, nil
+		}),
+	})
+	s.Require().NoError(err)
+
+	// when
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			headers := make(map[string]string)
+			s.NoError(provider.ApplyCredentials(context.Background(), headers))
+			s.Equal("Bearer "+accessToken, headers["Authorization"])
+		}()
+	}
+
+	s.Eventually(func() bool {
+		return atomic.LoadInt32(&calls) == 1
+	}, utils.DefaultTestTimeout, 10*time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	// then
+	s.EqualValues(1, atomic.LoadInt32(&calls))
+}
+
+func (s *oauthCredsProviderTestSuite) TestRetryRefreshWhileTokenIsValid() {
+	// given
+	truncateDefaultOAuthYamlCacheFile()
+	var calls int32
+	provider, err := NewOAuthCredentialsProvider(&OAuthProviderConfig{
+		Audience:    audience,
+		RefreshSkew: time.Minute,
+		TokenSource: OAuthTokenSourceFunc(func(context.Context) (*oauth2.Token, error) {
+			switch atomic.AddInt32(&calls, 1) {
+			case 1:
+				return &oauth2.Token{AccessToken: "first", Expiry: time.Now().Add(30 * time.Second)}, nil
+			case 2, 3:
+				return nil, errors.New("authorization server unavailable")
+			default:
+				return &oauth2.Token{AccessToken: "second", Expiry: time.Now().Add(time.Hour)}, nil
+			}
+		}),
+	})
+	s.Require().NoError(err)
+	defer provider.Close()
+	provider.refreshBackoff = worker.NewExponentialBackoffBuilder().MinDelay(time.Millisecond).MaxDelay(10 * time.Millisecond).Build()
+
+	headers := make(map[string]string)
+	s.Require().NoError(provider.ApplyCredentials(context.Background(), headers))
+	provider.lock.Lock()
+	provider.tokenReceivedAt = provider.token.Expiry.Add(-90 * time.Second)
+	provider.lock.Unlock()
+
+	// when
+	s.Require().NoError(provider.ApplyCredentials(context.Background(), headers))
+
+	// then
+	s.Equal("Bearer first", headers["Authorization"])
+	s.Eventually(func() bool {
+		return provider.currentToken().AccessToken == "second"
+	}, utils.DefaultTestTimeout, 10*time.Millisecond)
+	s.EqualValues(4, atomic.LoadInt32(&calls))
+}
+
 type custom struct {
 	provider         CredentialsProvider
 	shouldRetryCalls int
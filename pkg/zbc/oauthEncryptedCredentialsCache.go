@@ -0,0 +1,159 @@
+// Copyright © 2018 Camunda Services GmbH (info@camunda.com)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package zbc
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+const OAuthCacheKeyEnvVar = "ZEEBE_CLIENT_CACHE_KEY"
+const OAuthCacheKeyPathEnvVar = "ZEEBE_CLIENT_CACHE_KEY_PATH"
+const DefaultOAuthEncryptedCacheFile = "credentials.enc"
+
+const ErrOAuthCacheKeyMissing = Error("an encryption key is required for the encrypted OAuth credentials cache")
+
+var DefaultOauthEncryptedCachePath = filepath.Join(filepath.Dir(DefaultOauthYamlCachePath), DefaultOAuthEncryptedCacheFile)
+
+// scrypt parameters deriving the encryption key from the cache key, and length of the salt stored in the cache file
+const (
+	oauthCacheKeyScryptN = 1 << 15
+	oauthCacheKeyScryptR = 8
+	oauthCacheKeyScryptP = 1
+	oauthCacheSaltLength = 16
+)
+
+// NewOAuthEncryptedCredentialsCache returns a cache which stores credentials in a YAML file like the one returned by
+// NewOAuthYamlCredentialsCache, but encrypted with AES-GCM. The encryption key is derived from the given key with
+// scrypt and a random salt stored in the cache file, so the key may be a passphrase. If no path is given, defaults to
+// '$HOME/.camunda/credentials.enc' (can be overridden by 'ZEEBE_CLIENT_CONFIG_PATH').
+//
+// A cache file which cannot be decrypted, e.g. after rotating the key, is ignored and overwritten on the next update.
+func NewOAuthEncryptedCredentialsCache(path string, key []byte) (OAuthCredentialsCache, error) {
+	if len(key) == 0 {
+		return nil, ErrOAuthCacheKeyMissing
+	}
+
+	cipher := &oauthCacheCipher{key: key}
+	return newOAuthYamlCredentialsCache(&oauthYamlCredentialsCache{
+		path:   resolveOAuthCachePath(path, DefaultOauthEncryptedCachePath),
+		encode: cipher.encrypt,
+		decode: cipher.decrypt,
+	})
+}
+
+// oauthCacheCipher encrypts the cache file as salt, nonce and ciphertext. Deriving a key is slow by design, so the
+// key derived for the last salt is kept, and files are encrypted with the salt of the file read last.
+type oauthCacheCipher struct {
+	key []byte
+
+	lock sync.Mutex
+	salt []byte
+	aead cipher.AEAD
+}
+
+// aeadFor returns the cipher for the salt, or for the current salt, or a new random one, if salt is nil
+func (c *oauthCacheCipher) aeadFor(salt []byte) ([]byte, cipher.AEAD, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.aead != nil && (salt == nil || bytes.Equal(salt, c.salt)) {
+		return c.salt, c.aead, nil
+	}
+
+	if salt == nil {
+		salt = make([]byte, oauthCacheSaltLength)
+		if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	derivedKey, err := scrypt.Key(c.key, salt, oauthCacheKeyScryptN, oauthCacheKeyScryptR, oauthCacheKeyScryptP, 32)
+	if err != nil {
+		return nil, nil, err
+	}
+	block, err := aes.NewCipher(derivedKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	c.salt, c.aead = append([]byte{}, salt...), aead
+	return c.salt, c.aead, nil
+}
+
+func (c *oauthCacheCipher) encrypt(plaintext []byte) ([]byte, error) {
+	salt, aead, err := c.aeadFor(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]byte, len(salt)+aead.NonceSize(), len(salt)+aead.NonceSize()+len(plaintext)+aead.Overhead())
+	copy(out, salt)
+	nonce := out[len(salt):]
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(out, nonce, plaintext, nil), nil
+}
+
+func (c *oauthCacheCipher) decrypt(ciphertext []byte) ([]byte, error) {
+	if len(ciphertext) < oauthCacheSaltLength {
+		return nil, errors.New("encrypted contents are too short")
+	}
+
+	salt, ciphertext := ciphertext[:oauthCacheSaltLength], ciphertext[oauthCacheSaltLength:]
+	_, aead, err := c.aeadFor(salt)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ciphertext) < aead.NonceSize() {
+		return nil, errors.New("encrypted contents are too short")
+	}
+	nonce, ciphertext := ciphertext[:aead.NonceSize()], ciphertext[aead.NonceSize():]
+	return aead.Open(nil, nonce, ciphertext, nil)
+}
+
+// reads the cache encryption key from 'ZEEBE_CLIENT_CACHE_KEY', or from the file at 'ZEEBE_CLIENT_CACHE_KEY_PATH'
+func getOAuthCacheKeyFromEnv() ([]byte, error) {
+	if key := env.get(OAuthCacheKeyEnvVar); key != "" {
+		return []byte(key), nil
+	}
+
+	if keyPath := env.get(OAuthCacheKeyPathEnvVar); keyPath != "" {
+		key, err := os.ReadFile(keyPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read OAuth credentials cache key: %w", err)
+		}
+		return []byte(strings.TrimSpace(string(key))), nil
+	}
+
+	return nil, ErrOAuthCacheKeyMissing
+}
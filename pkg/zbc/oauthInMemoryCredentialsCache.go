@@ -0,0 +1,53 @@
+// Copyright © 2018 Camunda Services GmbH (info@camunda.com)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package zbc
+
+import (
+	"golang.org/x/oauth2"
+	"sync"
+)
+
+type oauthInMemoryCredentialsCache struct {
+	audiences map[string]*oauth2.Token
+	lock      sync.RWMutex
+}
+
+// NewOAuthInMemoryCredentialsCache returns a cache which only keeps credentials in memory, so they are never written
+// to disk but have to be fetched again by every process
+func NewOAuthInMemoryCredentialsCache() OAuthCredentialsCache {
+	return &oauthInMemoryCredentialsCache{audiences: make(map[string]*oauth2.Token)}
+}
+
+// Refresh does nothing, as there is nothing to re-populate the cache from
+func (cache *oauthInMemoryCredentialsCache) Refresh() error {
+	return nil
+}
+
+// Get returns the cached credentials for the given audience or nil
+func (cache *oauthInMemoryCredentialsCache) Get(audience string) *oauth2.Token {
+	cache.lock.RLock()
+	defer cache.lock.RUnlock()
+
+	return cache.audiences[audience]
+}
+
+// Update sets the credentials for the given audience
+func (cache *oauthInMemoryCredentialsCache) Update(audience string, credentials *oauth2.Token) error {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+
+	cache.audiences[audience] = credentials
+	return nil
+}
@@ -0,0 +1,316 @@
+// Copyright © 2018 Camunda Services GmbH (info@camunda.com)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package zbc
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	_ "crypto/sha256" // registers the hash functions used to sign client assertions
+	_ "crypto/sha512"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+// JWTBearerClientAssertionType is the client assertion type for a client authenticating with a signed JWT, see RFC 7523
+const JWTBearerClientAssertionType = "urn:ietf:params:oauth:client-assertion-type:jwt-bearer"
+
+// TokenExchangeGrantType is the grant type to exchange a token for an access token, see RFC 8693
+const TokenExchangeGrantType = "urn:ietf:params:oauth:grant-type:token-exchange"
+
+// JWTTokenType is the default type of the subject token for a token exchange
+const JWTTokenType = "urn:ietf:params:oauth:token-type:jwt"
+
+// DefaultClientAssertionLifetime is how long a signed client assertion is valid for
+const DefaultClientAssertionLifetime = 1 * time.Minute
+
+// OAuthTokenSource requests a new access token from an authorization server. The OAuthCredentialsProvider takes care
+// of caching the returned tokens and of refreshing them, so implementations should always request a new token.
+//
+// The context passed to Token carries the HTTP client to use under the oauth2.HTTPClient key, as well as the request
+// timeout of the provider.
+type OAuthTokenSource interface {
+	Token(ctx context.Context) (*oauth2.Token, error)
+}
+
+// OAuthTokenSourceFunc adapts a function to an OAuthTokenSource.
+type OAuthTokenSourceFunc func(ctx context.Context) (*oauth2.Token, error)
+
+// Token calls f(ctx).
+func (f OAuthTokenSourceFunc) Token(ctx context.Context) (*oauth2.Token, error) {
+	return f(ctx)
+}
+
+// NewClientSecretTokenSource returns a token source which uses the client credentials grant, authenticating the client
+// with its client secret.
+func NewClientSecretTokenSource(config *OAuthProviderConfig) OAuthTokenSource {
+	return newClientCredentialsConfig(config)
+}
+
+// JWTClientAssertionConfig configures a token source using the client credentials grant where the client
+// authenticates with a JWT signed by its private key ('private_key_jwt', see RFC 7523).
+type JWTClientAssertionConfig struct {
+	// ClientID is used as issuer and subject of the assertion.
+	ClientID string
+	// TokenURL is the authorization server's token endpoint, which is also the audience of the assertion.
+	TokenURL string
+	// Audience is the audience of the requested access token.
+	Audience string
+	// Scopes are the optional scopes of the requested access token.
+	Scopes []string
+	// PrivateKey is a PEM encoded RSA or ECDSA private key, in PKCS #1, PKCS #8 or SEC 1 form.
+	PrivateKey []byte
+	// KeyID is the optional 'kid' header of the assertion, which tells the authorization server which key to verify it with.
+	KeyID string
+	// Lifetime of each assertion; defaults to DefaultClientAssertionLifetime.
+	Lifetime time.Duration
+}
+
+type jwtClientAssertionTokenSource struct {
+	config    JWTClientAssertionConfig
+	signer    crypto.Signer
+	algorithm string
+	hash      crypto.Hash
+}
+
+// NewJWTClientAssertionTokenSource returns a token source which signs a new client assertion for every token request.
+func NewJWTClientAssertionTokenSource(config *JWTClientAssertionConfig) (OAuthTokenSource, error) {
+	signer, err := parsePrivateKey(config.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	source := &jwtClientAssertionTokenSource{config: *config, signer: signer}
+	if source.config.Lifetime <= 0 {
+		source.config.Lifetime = DefaultClientAssertionLifetime
+	}
+
+	switch key := signer.(type) {
+	case *rsa.PrivateKey:
+		source.algorithm, source.hash = "RS256", crypto.SHA256
+	case *ecdsa.PrivateKey:
+		switch key.Curve {
+		case elliptic.P256():
+			source.algorithm, source.hash = "ES256", crypto.SHA256
+		case elliptic.P384():
+			source.algorithm, source.hash = "ES384", crypto.SHA384
+		case elliptic.P521():
+			source.algorithm, source.hash = "ES512", crypto.SHA512
+		default:
+			return nil, fmt.Errorf("expected ECDSA client assertion key on curve P-256, P-384 or P-521, but got %s", key.Curve.Params().Name)
+		}
+	default:
+		return nil, fmt.Errorf("expected RSA or ECDSA client assertion key, but got %T", key)
+	}
+
+	return source, nil
+}
+
+func (s *jwtClientAssertionTokenSource) Token(ctx context.Context) (*oauth2.Token, error) {
+	assertion, err := s.sign(time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign client assertion: %w", err)
+	}
+
+	config := &clientcredentials.Config{
+		ClientID: s.config.ClientID,
+		TokenURL: s.config.TokenURL,
+		Scopes:   s.config.Scopes,
+		EndpointParams: map[string][]string{
+			"audience":              {s.config.Audience},
+			"client_assertion_type": {JWTBearerClientAssertionType},
+			"client_assertion":      {assertion},
+		},
+		AuthStyle: oauth2.AuthStyleInParams,
+	}
+
+	return config.Token(ctx)
+}
+
+func (s *jwtClientAssertionTokenSource) sign(now time.Time) (string, error) {
+	header := map[string]string{"alg": s.algorithm, "typ": "JWT"}
+	if s.config.KeyID != "" {
+		header["kid"] = s.config.KeyID
+	}
+
+	claims := map[string]interface{}{
+		"iss": s.config.ClientID,
+		"sub": s.config.ClientID,
+		"aud": s.config.TokenURL,
+		"jti": uuid.NewString(),
+		"iat": now.Unix(),
+		"exp": now.Add(s.config.Lifetime).Unix(),
+	}
+
+	encodedHeader, err := encodeJWTSegment(header)
+	if err != nil {
+		return "", err
+	}
+	encodedClaims, err := encodeJWTSegment(claims)
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := encodedHeader + "." + encodedClaims
+	digest := s.hash.New()
+	digest.Write([]byte(signingInput))
+
+	signature, err := s.signDigest(digest.Sum(nil))
+	if err != nil {
+		return "", err
+	}
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+func (s *jwtClientAssertionTokenSource) signDigest(digest []byte) ([]byte, error) {
+	if key, ok := s.signer.(*ecdsa.PrivateKey); ok {
+		// JWS expects the raw r || s form rather than the ASN.1 encoding
+		r, sig, err := ecdsa.Sign(rand.Reader, key, digest)
+		if err != nil {
+			return nil, err
+		}
+
+		size := (key.Curve.Params().BitSize + 7) / 8
+		signature := make([]byte, 2*size)
+		r.FillBytes(signature[:size])
+		sig.FillBytes(signature[size:])
+		return signature, nil
+	}
+
+	return s.signer.Sign(rand.Reader, digest, s.hash)
+}
+
+func encodeJWTSegment(value interface{}) (string, error) {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func parsePrivateKey(pemBytes []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(pemBytes)
+	if block == nil {
+		return nil, fmt.Errorf("expected PEM encoded client assertion key, but found no PEM block")
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParseECPrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse client assertion key: %w", err)
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("expected client assertion key to be able to sign, but got %T", key)
+	}
+
+	return signer, nil
+}
+
+// TokenExchangeConfig configures a token source which exchanges a token read from a file for an access token
+// (see RFC 8693), e.g. a Kubernetes projected service account token.
+type TokenExchangeConfig struct {
+	// ClientID is the optional client identifier sent along with the exchange request.
+	ClientID string
+	// ClientSecret is the optional client secret sent along with the exchange request.
+	ClientSecret string
+	// TokenURL is the authorization server's token endpoint.
+	TokenURL string
+	// Audience is the audience of the requested access token.
+	Audience string
+	// Scopes are the optional scopes of the requested access token.
+	Scopes []string
+	// SubjectTokenPath is the file the subject token is read from. It is read again on each exchange, so rotated
+	// tokens are picked up.
+	SubjectTokenPath string
+	// SubjectTokenType is the type of the subject token; defaults to JWTTokenType.
+	SubjectTokenType string
+}
+
+type tokenExchangeTokenSource struct {
+	config TokenExchangeConfig
+}
+
+// NewTokenExchangeTokenSource returns a token source which exchanges the token stored at the configured path for an
+// access token.
+func NewTokenExchangeTokenSource(config *TokenExchangeConfig) OAuthTokenSource {
+	source := &tokenExchangeTokenSource{config: *config}
+	if source.config.SubjectTokenType == "" {
+		source.config.SubjectTokenType = JWTTokenType
+	}
+
+	return source
+}
+
+func (s *tokenExchangeTokenSource) Token(ctx context.Context) (*oauth2.Token, error) {
+	subjectToken, err := os.ReadFile(s.config.SubjectTokenPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read subject token: %w", err)
+	}
+
+	config := &clientcredentials.Config{
+		ClientID:     s.config.ClientID,
+		ClientSecret: s.config.ClientSecret,
+		TokenURL:     s.config.TokenURL,
+		Scopes:       s.config.Scopes,
+		EndpointParams: map[string][]string{
+			"grant_type":         {TokenExchangeGrantType},
+			"audience":           {s.config.Audience},
+			"subject_token":      {strings.TrimSpace(string(subjectToken))},
+			"subject_token_type": {s.config.SubjectTokenType},
+		},
+		AuthStyle: oauth2.AuthStyleInParams,
+	}
+
+	return config.Token(ctx)
+}
+
+func newClientCredentialsConfig(config *OAuthProviderConfig) *clientcredentials.Config {
+	tokenConfig := &clientcredentials.Config{
+		ClientID:       config.ClientID,
+		ClientSecret:   config.ClientSecret,
+		EndpointParams: map[string][]string{"audience": {config.Audience}},
+		TokenURL:       config.AuthorizationServerURL,
+		AuthStyle:      oauth2.AuthStyleInParams,
+	}
+
+	if config.Scope != "" {
+		tokenConfig.Scopes = []string{config.Scope}
+	}
+
+	return tokenConfig
+}
@@ -0,0 +1,263 @@
+// Copyright © 2018 Camunda Services GmbH (info@camunda.com)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package zbc
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"golang.org/x/oauth2"
+)
+
+type oauthTokenSourceTestSuite struct {
+	*envSuite
+}
+
+func TestOAuthTokenSourceSuite(t *testing.T) {
+	suite.Run(t, &oauthTokenSourceTestSuite{envSuite: new(envSuite)})
+}
+
+func (s *oauthTokenSourceTestSuite) TestRSAClientAssertion() {
+	// given
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	s.Require().NoError(err)
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+
+	var assertion string
+	authzServer := s.mockTokenEndpoint(func(query url.Values) {
+		s.Equal("client_credentials", query.Get("grant_type"))
+		s.Equal(JWTBearerClientAssertionType, query.Get("client_assertion_type"))
+		s.Equal(clientID, query.Get("client_id"))
+		s.Equal(audience, query.Get("audience"))
+		s.False(query.Has("client_secret"))
+		assertion = query.Get("client_assertion")
+	})
+	defer authzServer.Close()
+
+	source, err := NewJWTClientAssertionTokenSource(&JWTClientAssertionConfig{
+		ClientID:   clientID,
+		TokenURL:   authzServer.URL,
+		Audience:   audience,
+		PrivateKey: keyPEM,
+		KeyID:      "key-1",
+	})
+	s.Require().NoError(err)
+
+	// when
+	token, err := source.Token(context.Background())
+
+	// then
+	s.Require().NoError(err)
+	s.Equal(accessToken, token.AccessToken)
+
+	header, claims, signingInput, signature := s.decodeJWT(assertion)
+	s.Equal("RS256", header["alg"])
+	s.Equal("key-1", header["kid"])
+	s.Equal(clientID, claims["iss"])
+	s.Equal(clientID, claims["sub"])
+	s.Equal(authzServer.URL, claims["aud"])
+	s.NotEmpty(claims["jti"])
+
+	digest := sha256.Sum256([]byte(signingInput))
+	s.NoError(rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, digest[:], signature))
+}
+
+func (s *oauthTokenSourceTestSuite) TestECDSAClientAssertion() {
+	// given
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	s.Require().NoError(err)
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(key)
+	s.Require().NoError(err)
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8})
+
+	var assertion string
+	authzServer := s.mockTokenEndpoint(func(query url.Values) {
+		assertion = query.Get("client_assertion")
+	})
+	defer authzServer.Close()
+
+	source, err := NewJWTClientAssertionTokenSource(&JWTClientAssertionConfig{
+		ClientID:   clientID,
+		TokenURL:   authzServer.URL,
+		Audience:   audience,
+		PrivateKey: keyPEM,
+	})
+	s.Require().NoError(err)
+
+	// when
+	_, err = source.Token(context.Background())
+
+	// then
+	s.Require().NoError(err)
+
+	header, _, signingInput, signature := s.decodeJWT(assertion)
+	s.Equal("ES256", header["alg"])
+	s.NotContains(header, "kid")
+	s.Len(signature, 64)
+
+	digest := sha256.Sum256([]byte(signingInput))
+	r, sig := new(big.Int).SetBytes(signature[:32]), new(big.Int).SetBytes(signature[32:])
+	s.True(ecdsa.Verify(&key.PublicKey, digest[:], r, sig))
+}
+
+func (s *oauthTokenSourceTestSuite) TestRejectInvalidClientAssertionKey() {
+	// when
+	_, err := NewJWTClientAssertionTokenSource(&JWTClientAssertionConfig{
+		ClientID:   clientID,
+		TokenURL:   "http://foo",
+		Audience:   audience,
+		PrivateKey: []byte("not a key"),
+	})
+
+	// then
+	s.Error(err)
+}
+
+func (s *oauthTokenSourceTestSuite) TestTokenExchangeRereadsSubjectToken() {
+	// given
+	subjectTokenPath := filepath.Join(s.T().TempDir(), "token")
+	s.Require().NoError(os.WriteFile(subjectTokenPath, []byte("first\n"), 0600))
+
+	var subjectTokens []string
+	authzServer := s.mockTokenEndpoint(func(query url.Values) {
+		s.Equal(TokenExchangeGrantType, query.Get("grant_type"))
+		s.Equal(JWTTokenType, query.Get("subject_token_type"))
+		s.Equal(audience, query.Get("audience"))
+		subjectTokens = append(subjectTokens, query.Get("subject_token"))
+	})
+	defer authzServer.Close()
+
+	source := NewTokenExchangeTokenSource(&TokenExchangeConfig{
+		TokenURL:         authzServer.URL,
+		Audience:         audience,
+		SubjectTokenPath: subjectTokenPath,
+	})
+
+	// when
+	_, err := source.Token(context.Background())
+	s.Require().NoError(err)
+	s.Require().NoError(os.WriteFile(subjectTokenPath, []byte("second"), 0600))
+	_, err = source.Token(context.Background())
+	s.Require().NoError(err)
+
+	// then
+	s.Equal([]string{"first", "second"}, subjectTokens)
+}
+
+func (s *oauthTokenSourceTestSuite) TestProviderWithClientAssertionKeyPath() {
+	// given
+	truncateDefaultOAuthYamlCacheFile()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	s.Require().NoError(err)
+	keyPath := filepath.Join(s.T().TempDir(), "key.pem")
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	s.Require().NoError(os.WriteFile(keyPath, keyPEM, 0600))
+
+	authzServer := s.mockTokenEndpoint(func(query url.Values) {
+		s.NotEmpty(query.Get("client_assertion"))
+	})
+	defer authzServer.Close()
+
+	env.set(OAuthClientAssertionKeyPathEnvVar, keyPath)
+	provider, err := NewOAuthCredentialsProvider(&OAuthProviderConfig{
+		ClientID:               clientID,
+		Audience:               audience,
+		AuthorizationServerURL: authzServer.URL,
+	})
+	s.Require().NoError(err)
+
+	// when
+	headers := make(map[string]string)
+	err = provider.ApplyCredentials(context.Background(), headers)
+
+	// then
+	s.NoError(err)
+	s.Equal("Bearer "+accessToken, headers["Authorization"])
+	s.Nil(provider.TokenConfig)
+}
+
+func (s *oauthTokenSourceTestSuite) TestProviderWithCustomTokenSource() {
+	// given
+	truncateDefaultOAuthYamlCacheFile()
+	calls := 0
+	provider, err := NewOAuthCredentialsProvider(&OAuthProviderConfig{
+		Audience: audience,
+		TokenSource: OAuthTokenSourceFunc(func(context.Context) (*oauth2.Token, error) {
+			calls++
+			return &oauth2.Token{AccessToken: accessToken, TokenType: "Bearer"}, nil
+		}),
+	})
+	s.Require().NoError(err)
+
+	// when
+	headers := make(map[string]string)
+	err = provider.ApplyCredentials(context.Background(), headers)
+
+	// then
+	s.NoError(err)
+	s.Equal("Bearer "+accessToken, headers["Authorization"])
+	s.Equal(1, calls)
+}
+
+func (s *oauthTokenSourceTestSuite) mockTokenEndpoint(assertRequest func(url.Values)) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		body, err := io.ReadAll(request.Body)
+		s.Require().NoError(err)
+
+		query, err := url.ParseQuery(string(body))
+		s.Require().NoError(err)
+		assertRequest(query)
+
+		writer.Header().Set("Content-Type", "application/json")
+		_, err = writer.Write([]byte(`{"access_token": "` + accessToken + `", "expires_in": 3600, "token_type": "bearer"}`))
+		s.Require().NoError(err)
+	}))
+}
+
+func (s *oauthTokenSourceTestSuite) decodeJWT(jwt string) (map[string]interface{}, map[string]interface{}, string, []byte) {
+	parts := strings.Split(jwt, ".")
+	s.Require().Len(parts, 3)
+
+	var header, claims map[string]interface{}
+	for i, target := range []*map[string]interface{}{&header, &claims} {
+		segment, err := base64.RawURLEncoding.DecodeString(parts[i])
+		s.Require().NoError(err)
+		s.Require().NoError(json.Unmarshal(segment, target))
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	s.Require().NoError(err)
+
+	return header, claims, parts[0] + "." + parts[1], signature
+}
@@ -0,0 +1,340 @@
+// Copyright © 2018 Camunda Services GmbH (info@camunda.com)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package zbc
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/camunda-community-hub/zeebe-client-go/v8/pkg/commands"
+	"github.com/camunda-community-hub/zeebe-client-go/v8/pkg/entities"
+	"github.com/camunda-community-hub/zeebe-client-go/v8/pkg/worker"
+	"github.com/google/uuid"
+)
+
+const (
+	// DefaultResultAwaiterJobTypePrefix prefixes the unique job type of result awaiters without a configured one
+	DefaultResultAwaiterJobTypePrefix = "await-result-"
+	// ResultAwaiterJobTypeVariable is the variable set by ResultAwaiter.SendAndAwait to the job type of the awaiter, to
+	// be used by the job type expression of the element signaling the result, i.e. '= awaitResultJobType'
+	ResultAwaiterJobTypeVariable     = "awaitResultJobType"
+	DefaultResultAwaiterUnclaimedTTL = time.Minute
+)
+
+// ErrResultAwaiterClosed is the error of the results awaited from a closed ResultAwaiter
+var ErrResultAwaiterClosed = errors.New("result awaiter is closed")
+
+// ProcessResult is the job of the awaited process instance reaching the element with the job type of the awaiter. Its
+// variables are the variables of the process instance at that point.
+type ProcessResult struct {
+	entities.Job
+}
+
+// ResultAwaiterOptions configure a ResultAwaiter
+type ResultAwaiterOptions struct {
+	// JobType is the job type of the element signaling the result of awaited process instances, usually an end event
+	// with a job. Defaults to a job type unique to the awaiter, so that awaiters of several clients or replicas do not
+	// take the results of each other. A fixed job type allows a restarted client to await instances created before.
+	//
+	// No other worker ever activates the jobs of the default job type, so the awaiter completes those it holds once
+	// UnclaimedTTL elapsed, or when it is closed, letting their process instances go on. Process instances reaching the
+	// element after the awaiter is closed keep waiting for a job no worker activates, and must be cancelled or have
+	// their job completed by other means, e.g. with zbctl. With a fixed job type, the jobs are never completed unless
+	// awaited, so that a restarted client can still await them.
+	JobType string
+	// UnclaimedTTL is how long the jobs of process instances which are not awaited yet are held, which covers process
+	// instances reaching the element before Await is called. Afterwards, the jobs of the default job type are
+	// completed, and those of a fixed job type are left to time out, so that they can be activated again. It must be
+	// shorter than the job timeout of the worker. Defaults to DefaultResultAwaiterUnclaimedTTL.
+	UnclaimedTTL time.Duration
+}
+
+// unclaimedJob is a job of a process instance which is not awaited yet, and is completed once it is
+type unclaimedJob struct {
+	client    worker.JobClient
+	job       entities.Job
+	expiresAt time.Time
+	// expiry completes the job once UnclaimedTTL elapsed, for the default job type only
+	expiry *time.Timer
+}
+
+// ResultAwaiter awaits the result of process instances which may run for hours, without holding a request open like
+// CreateInstanceWithResultCommand. The process signals its result with an element creating a job of the awaiter's job
+// type, usually through the job type expression '= awaitResultJobType', which ResultAwaiter.SendAndAwait sets. A job
+// worker of the awaiter completes the jobs of awaited process instances only, and resolves the futures awaiting them.
+// Since the worker keeps polling, awaiting results survives reconnecting to the gateway.
+type ResultAwaiter struct {
+	jobType      string
+	unclaimedTTL time.Duration
+	// completeUnclaimed is set for the default job type, whose jobs no other worker activates
+	completeUnclaimed bool
+	worker            worker.JobWorker
+
+	mutex     sync.Mutex
+	closed    bool
+	waiters   map[int64][]chan *ProcessResult
+	unclaimed map[int64]unclaimedJob
+}
+
+// NewResultAwaiter opens the job worker of the result awaiter, which must be closed to stop it
+func NewResultAwaiter(client Client, options ResultAwaiterOptions) *ResultAwaiter {
+	awaiter := newResultAwaiter(options)
+	awaiter.worker = client.NewJobWorker().JobType(awaiter.jobType).Handler(awaiter.handle).Open()
+	return awaiter
+}
+
+func newResultAwaiter(options ResultAwaiterOptions) *ResultAwaiter {
+	awaiter := &ResultAwaiter{
+		jobType:      options.JobType,
+		unclaimedTTL: options.UnclaimedTTL,
+		waiters:      make(map[int64][]chan *ProcessResult),
+		unclaimed:    make(map[int64]unclaimedJob),
+	}
+	if awaiter.jobType == "" {
+		awaiter.jobType = DefaultResultAwaiterJobTypePrefix + uuid.NewString()
+		awaiter.completeUnclaimed = true
+	}
+	if awaiter.unclaimedTTL <= 0 {
+		awaiter.unclaimedTTL = DefaultResultAwaiterUnclaimedTTL
+	}
+	return awaiter
+}
+
+// JobType returns the job type of the element signaling the result of awaited process instances
+func (awaiter *ResultAwaiter) JobType() string {
+	return awaiter.jobType
+}
+
+// Await returns the future result of the process instance. The future fails with the error of ctx if it is done
+// before the process instance reaches the element with the job type of the awaiter.
+func (awaiter *ResultAwaiter) Await(ctx context.Context, processInstanceKey int64) *commands.Future[*ProcessResult] {
+	results, unclaimed, err := awaiter.register(processInstanceKey)
+
+	return commands.SendAsync(ctx, nil, func(ctx context.Context) (*ProcessResult, error) {
+		if err != nil {
+			return nil, err
+		}
+
+		if unclaimed != nil {
+			if err := completeResultJob(ctx, unclaimed.client, unclaimed.job); err != nil {
+				return nil, err
+			}
+			return &ProcessResult{Job: unclaimed.job}, nil
+		}
+
+		select {
+		case result, ok := <-results:
+			if !ok {
+				return nil, ErrResultAwaiterClosed
+			}
+			return result, nil
+		case <-ctx.Done():
+			awaiter.unregister(processInstanceKey, results)
+			return nil, ctx.Err()
+		}
+	})
+}
+
+// SendAndAwait creates a process instance with the command and the variables, and awaits its result. The variable
+// ResultAwaiterJobTypeVariable is added to the variables, set to the job type of the awaiter.
+func (awaiter *ResultAwaiter) SendAndAwait(ctx context.Context, command commands.CreateInstanceCommandStep3, variables map[string]interface{}) (*ProcessResult, error) {
+	withJobType := make(map[string]interface{}, len(variables)+1)
+	for name, value := range variables {
+		withJobType[name] = value
+	}
+	withJobType[ResultAwaiterJobTypeVariable] = awaiter.jobType
+
+	dispatch, err := command.VariablesFromMap(withJobType)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := dispatch.Send(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return awaiter.Await(ctx, response.GetProcessInstanceKey()).Get(ctx)
+}
+
+// Close stops the job worker of the awaiter and fails the futures still awaiting a result. The jobs held for process
+// instances which are not awaited are completed for the default job type, and left to time out for a fixed one.
+func (awaiter *ResultAwaiter) Close() {
+	if awaiter.worker != nil {
+		awaiter.worker.Close()
+	}
+
+	awaiter.mutex.Lock()
+	awaiter.closed = true
+	for _, waiters := range awaiter.waiters {
+		for _, results := range waiters {
+			close(results)
+		}
+	}
+	unclaimed := awaiter.unclaimed
+	awaiter.waiters = map[int64][]chan *ProcessResult{}
+	awaiter.unclaimed = map[int64]unclaimedJob{}
+	awaiter.mutex.Unlock()
+
+	// expiry timers firing meanwhile find no job to complete, as the held jobs were removed above
+	for _, job := range unclaimed {
+		if job.expiry != nil {
+			job.expiry.Stop()
+			awaiter.completeUnclaimedJob(job)
+		}
+	}
+}
+
+// register returns the channel receiving the result of the process instance, or the job held for it if the process
+// instance reached the element before
+func (awaiter *ResultAwaiter) register(processInstanceKey int64) (chan *ProcessResult, *unclaimedJob, error) {
+	awaiter.mutex.Lock()
+	defer awaiter.mutex.Unlock()
+
+	if awaiter.closed {
+		return nil, nil, ErrResultAwaiterClosed
+	}
+
+	awaiter.expireUnclaimed()
+	if unclaimed, ok := awaiter.unclaimed[processInstanceKey]; ok {
+		delete(awaiter.unclaimed, processInstanceKey)
+		if unclaimed.expiry != nil {
+			unclaimed.expiry.Stop()
+		}
+		return nil, &unclaimed, nil
+	}
+
+	results := make(chan *ProcessResult, 1)
+	awaiter.waiters[processInstanceKey] = append(awaiter.waiters[processInstanceKey], results)
+	return results, nil, nil
+}
+
+func (awaiter *ResultAwaiter) unregister(processInstanceKey int64, results chan *ProcessResult) {
+	awaiter.mutex.Lock()
+	defer awaiter.mutex.Unlock()
+
+	waiters := awaiter.waiters[processInstanceKey]
+	for i, waiter := range waiters {
+		if waiter == results {
+			waiters = append(waiters[:i], waiters[i+1:]...)
+			break
+		}
+	}
+
+	if len(waiters) == 0 {
+		delete(awaiter.waiters, processInstanceKey)
+	} else {
+		awaiter.waiters[processInstanceKey] = waiters
+	}
+}
+
+// handle completes the job of an awaited process instance and resolves the futures awaiting it. The jobs of other
+// process instances are held until they are awaited or UnclaimedTTL elapsed. Then, jobs of the default job type are
+// completed, while those of a fixed job type time out and can be activated again, e.g. by a restarted client with the
+// same job type. If completing the job fails, the job is activated again once it timed out.
+func (awaiter *ResultAwaiter) handle(client worker.JobClient, job entities.Job) {
+	if !awaiter.isAwaited(client, job) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), worker.DefaultRequestTimeout)
+	defer cancel()
+
+	if err := completeResultJob(ctx, client, job); err != nil {
+		log.Printf("Failed to complete result job %d of process instance %d: %v\n", job.Key, job.ProcessInstanceKey, err)
+		return
+	}
+
+	awaiter.resolve(&ProcessResult{Job: job})
+}
+
+// isAwaited returns whether the process instance of the job is awaited, and holds the job otherwise
+func (awaiter *ResultAwaiter) isAwaited(client worker.JobClient, job entities.Job) bool {
+	awaiter.mutex.Lock()
+	defer awaiter.mutex.Unlock()
+
+	if _, ok := awaiter.waiters[job.ProcessInstanceKey]; ok {
+		return true
+	}
+
+	if awaiter.closed {
+		return false
+	}
+
+	awaiter.expireUnclaimed()
+	if previous, ok := awaiter.unclaimed[job.ProcessInstanceKey]; ok && previous.expiry != nil {
+		previous.expiry.Stop()
+	}
+
+	unclaimed := unclaimedJob{client: client, job: job, expiresAt: time.Now().Add(awaiter.unclaimedTTL)}
+	if awaiter.completeUnclaimed {
+		unclaimed.expiry = time.AfterFunc(awaiter.unclaimedTTL, func() { awaiter.completeExpired(job) })
+	}
+	awaiter.unclaimed[job.ProcessInstanceKey] = unclaimed
+	return false
+}
+
+// expireUnclaimed drops the jobs of a fixed job type held for longer than UnclaimedTTL, which must be called with the
+// mutex held. The jobs of the default job type are completed by their expiry timer instead.
+func (awaiter *ResultAwaiter) expireUnclaimed() {
+	now := time.Now()
+	for key, unclaimed := range awaiter.unclaimed {
+		if unclaimed.expiry == nil && now.After(unclaimed.expiresAt) {
+			delete(awaiter.unclaimed, key)
+		}
+	}
+}
+
+// completeExpired completes the job held for longer than UnclaimedTTL, unless it was awaited or replaced meanwhile
+func (awaiter *ResultAwaiter) completeExpired(job entities.Job) {
+	awaiter.mutex.Lock()
+	unclaimed, ok := awaiter.unclaimed[job.ProcessInstanceKey]
+	if ok && unclaimed.job.Key == job.Key {
+		delete(awaiter.unclaimed, job.ProcessInstanceKey)
+	}
+	awaiter.mutex.Unlock()
+
+	if ok && unclaimed.job.Key == job.Key {
+		awaiter.completeUnclaimedJob(unclaimed)
+	}
+}
+
+func (awaiter *ResultAwaiter) completeUnclaimedJob(unclaimed unclaimedJob) {
+	ctx, cancel := context.WithTimeout(context.Background(), worker.DefaultRequestTimeout)
+	defer cancel()
+
+	if err := completeResultJob(ctx, unclaimed.client, unclaimed.job); err != nil {
+		log.Printf("Failed to complete unclaimed result job %d of process instance %d: %v\n", unclaimed.job.Key, unclaimed.job.ProcessInstanceKey, err)
+	}
+}
+
+func (awaiter *ResultAwaiter) resolve(result *ProcessResult) {
+	awaiter.mutex.Lock()
+	defer awaiter.mutex.Unlock()
+
+	waiters := awaiter.waiters[result.ProcessInstanceKey]
+	delete(awaiter.waiters, result.ProcessInstanceKey)
+	for _, results := range waiters {
+		results <- result
+	}
+}
+
+func completeResultJob(ctx context.Context, client worker.JobClient, job entities.Job) error {
+	_, err := client.NewCompleteJobCommand().JobKey(job.Key).Send(ctx)
+	return err
+}
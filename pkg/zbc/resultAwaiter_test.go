@@ -0,0 +1,235 @@
+// Copyright © 2018 Camunda Services GmbH (info@camunda.com)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package zbc
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/camunda-community-hub/zeebe-client-go/v8/pkg/entities"
+	"github.com/camunda-community-hub/zeebe-client-go/v8/pkg/pb"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc"
+)
+
+type resultAwaiterTestSuite struct {
+	*envSuite
+}
+
+func TestResultAwaiterSuite(t *testing.T) {
+	suite.Run(t, &resultAwaiterTestSuite{new(envSuite)})
+}
+
+// resultGateway creates process instance 42, which completes after a while by activating one job of the type set in
+// the variables of the instance
+type resultGateway struct {
+	pb.UnimplementedGatewayServer
+	jobType       atomic.Value
+	jobActivated  int32
+	completedJobs chan int64
+}
+
+func (g *resultGateway) CreateProcessInstance(_ context.Context, request *pb.CreateProcessInstanceRequest) (*pb.CreateProcessInstanceResponse, error) {
+	var variables map[string]interface{}
+	if err := json.Unmarshal([]byte(request.Variables), &variables); err != nil {
+		return nil, err
+	}
+	if jobType, ok := variables[ResultAwaiterJobTypeVariable].(string); ok {
+		g.jobType.Store(jobType)
+	}
+
+	return &pb.CreateProcessInstanceResponse{ProcessInstanceKey: 42}, nil
+}
+
+func (g *resultGateway) ActivateJobs(request *pb.ActivateJobsRequest, stream pb.Gateway_ActivateJobsServer) error {
+	if request.Type != g.jobType.Load() || !atomic.CompareAndSwapInt32(&g.jobActivated, 0, 1) {
+		return nil
+	}
+
+	return stream.Send(&pb.ActivateJobsResponse{Jobs: []*pb.ActivatedJob{{
+		Key:                1,
+		Type:               request.Type,
+		ProcessInstanceKey: 42,
+		Variables:          `{"approved": true}`,
+		CustomHeaders:      "{}",
+	}}})
+}
+
+func (g *resultGateway) CompleteJob(_ context.Context, request *pb.CompleteJobRequest) (*pb.CompleteJobResponse, error) {
+	g.completedJobs <- request.JobKey
+	return &pb.CompleteJobResponse{}, nil
+}
+
+func jobOfInstance(processInstanceKey int64) entities.Job {
+	return entities.Job{ActivatedJob: &pb.ActivatedJob{Key: processInstanceKey + 1, ProcessInstanceKey: processInstanceKey}}
+}
+
+func (s *resultAwaiterTestSuite) newClient(gateway pb.GatewayServer) Client {
+	lis, err := net.Listen("tcp", "0.0.0.0:0")
+	s.Require().NoError(err)
+	grpcServer := grpc.NewServer()
+	pb.RegisterGatewayServer(grpcServer, gateway)
+	go grpcServer.Serve(lis)
+	s.T().Cleanup(grpcServer.Stop)
+
+	client, err := NewClient(&ClientConfig{GatewayAddress: lis.Addr().String(), UsePlaintextConnection: true})
+	s.Require().NoError(err)
+	s.T().Cleanup(func() { _ = client.Close() })
+	return client
+}
+
+func (s *resultAwaiterTestSuite) TestSendAndAwait() {
+	// given
+	gateway := &resultGateway{completedJobs: make(chan int64, 1)}
+	client := s.newClient(gateway)
+
+	awaiter := NewResultAwaiter(client, ResultAwaiterOptions{})
+	defer awaiter.Close()
+
+	command := client.NewCreateInstanceCommand().BPMNProcessId("approval").LatestVersion()
+
+	// when
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	result, err := awaiter.SendAndAwait(ctx, command, map[string]interface{}{"amount": 12})
+
+	// then
+	s.Require().NoError(err)
+	s.Equal(awaiter.JobType(), gateway.jobType.Load())
+	s.Equal(int64(42), result.ProcessInstanceKey)
+	approved, err := entities.GetVariable[bool](entities.NewJobVariables(result.Job, nil, nil), "approved")
+	s.Require().NoError(err)
+	s.True(approved)
+	s.Equal(int64(1), <-gateway.completedJobs)
+}
+
+func (s *resultAwaiterTestSuite) TestDefaultJobTypeIsUniquePerAwaiter() {
+	first := newResultAwaiter(ResultAwaiterOptions{})
+	second := newResultAwaiter(ResultAwaiterOptions{})
+
+	s.True(strings.HasPrefix(first.JobType(), DefaultResultAwaiterJobTypePrefix))
+	s.NotEqual(first.JobType(), second.JobType())
+	s.Equal("process-done", newResultAwaiter(ResultAwaiterOptions{JobType: "process-done"}).JobType())
+}
+
+func (s *resultAwaiterTestSuite) TestAwaitCompletesJobsOfInstancesDoneBeforeAwait() {
+	// given
+	gateway := &resultGateway{completedJobs: make(chan int64, 1)}
+	client := s.newClient(gateway)
+	awaiter := newResultAwaiter(ResultAwaiterOptions{})
+	awaiter.handle(client, jobOfInstance(42))
+	s.Empty(gateway.completedJobs)
+
+	// when
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	result, err := awaiter.Await(ctx, 42).Get(ctx)
+
+	// then
+	s.Require().NoError(err)
+	s.Equal(int64(42), result.ProcessInstanceKey)
+	s.Equal(int64(43), <-gateway.completedJobs)
+	s.Empty(awaiter.unclaimed)
+}
+
+func (s *resultAwaiterTestSuite) TestUnclaimedJobsOfDefaultJobTypeAreCompleted() {
+	// given
+	gateway := &resultGateway{completedJobs: make(chan int64, 1)}
+	client := s.newClient(gateway)
+	awaiter := newResultAwaiter(ResultAwaiterOptions{UnclaimedTTL: 10 * time.Millisecond})
+
+	// when
+	awaiter.handle(client, jobOfInstance(42))
+
+	// then
+	select {
+	case jobKey := <-gateway.completedJobs:
+		s.Equal(int64(43), jobKey)
+	case <-time.After(5 * time.Second):
+		s.Fail("expected the unclaimed job to be completed")
+	}
+	awaiter.mutex.Lock()
+	defer awaiter.mutex.Unlock()
+	s.Empty(awaiter.unclaimed)
+}
+
+func (s *resultAwaiterTestSuite) TestUnclaimedJobsOfFixedJobTypeAreNotCompleted() {
+	// given
+	gateway := &resultGateway{completedJobs: make(chan int64, 1)}
+	client := s.newClient(gateway)
+	awaiter := newResultAwaiter(ResultAwaiterOptions{JobType: "process-done", UnclaimedTTL: 10 * time.Millisecond})
+
+	// when
+	awaiter.handle(client, jobOfInstance(42))
+	time.Sleep(20 * time.Millisecond)
+
+	// then
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := awaiter.Await(ctx, 42).Get(context.Background())
+	s.ErrorIs(err, context.DeadlineExceeded)
+	awaiter.Close()
+	s.Empty(gateway.completedJobs)
+}
+
+func (s *resultAwaiterTestSuite) TestCloseCompletesUnclaimedJobsOfDefaultJobType() {
+	// given
+	gateway := &resultGateway{completedJobs: make(chan int64, 1)}
+	client := s.newClient(gateway)
+	awaiter := newResultAwaiter(ResultAwaiterOptions{UnclaimedTTL: time.Hour})
+	awaiter.handle(client, jobOfInstance(42))
+
+	// when
+	awaiter.Close()
+
+	// then
+	s.Equal(int64(43), <-gateway.completedJobs)
+}
+
+func (s *resultAwaiterTestSuite) TestAwaitFailsWhenContextIsDone() {
+	// given
+	awaiter := newResultAwaiter(ResultAwaiterOptions{})
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	// when
+	_, err := awaiter.Await(ctx, 42).Get(context.Background())
+
+	// then
+	s.ErrorIs(err, context.DeadlineExceeded)
+	awaiter.mutex.Lock()
+	defer awaiter.mutex.Unlock()
+	s.Empty(awaiter.waiters)
+}
+
+func (s *resultAwaiterTestSuite) TestCloseFailsAwaitedResults() {
+	// given
+	awaiter := newResultAwaiter(ResultAwaiterOptions{})
+	future := awaiter.Await(context.Background(), 42)
+
+	// when
+	awaiter.Close()
+
+	// then
+	_, err := future.Get(context.Background())
+	s.ErrorIs(err, ErrResultAwaiterClosed)
+	_, err = awaiter.Await(context.Background(), 43).Get(context.Background())
+	s.ErrorIs(err, ErrResultAwaiterClosed)
+}
@@ -0,0 +1,193 @@
+// Copyright © 2018 Camunda Services GmbH (info@camunda.com)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package zbc
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const BearerTokenEnvVar = "ZEEBE_BEARER_TOKEN"
+const APIKeyEnvVar = "ZEEBE_API_KEY"
+const APIKeyHeaderEnvVar = "ZEEBE_API_KEY_HEADER"
+const TokenPathEnvVar = "ZEEBE_TOKEN_PATH"
+
+const DefaultAPIKeyHeader = "x-api-key"
+
+// DefaultFileTokenRefreshInterval is how often a token without an expiry is re-read from its file
+const DefaultFileTokenRefreshInterval = time.Minute
+
+const ErrEmptyBearerToken = Error("expected to find non-empty bearer token")
+const ErrEmptyAPIKey = Error("expected to find non-empty API key")
+
+// BearerTokenCredentialsProvider adds a static bearer token to the authorization header of each call.
+type BearerTokenCredentialsProvider struct {
+	token string
+}
+
+// NewBearerTokenCredentialsProvider returns a provider for the given token. Can be configured with the environment
+// variable 'ZEEBE_BEARER_TOKEN'.
+func NewBearerTokenCredentialsProvider(token string) (*BearerTokenCredentialsProvider, error) {
+	if token == "" {
+		return nil, ErrEmptyBearerToken
+	}
+
+	return &BearerTokenCredentialsProvider{token: token}, nil
+}
+
+// ApplyCredentials adds the token to the authorization header.
+func (p *BearerTokenCredentialsProvider) ApplyCredentials(_ context.Context, headers map[string]string) error {
+	headers["Authorization"] = "Bearer " + p.token
+	return nil
+}
+
+// ShouldRetryRequest always returns false, as the token never changes.
+func (p *BearerTokenCredentialsProvider) ShouldRetryRequest(_ context.Context, _ error) bool {
+	return false
+}
+
+// APIKeyCredentialsProvider adds a static API key to a custom metadata header of each call.
+type APIKeyCredentialsProvider struct {
+	header string
+	key    string
+}
+
+// NewAPIKeyCredentialsProvider returns a provider which sends the key in the given header, or in 'x-api-key' if no
+// header is given. Can be configured with the environment variables 'ZEEBE_API_KEY' and 'ZEEBE_API_KEY_HEADER'.
+func NewAPIKeyCredentialsProvider(header, key string) (*APIKeyCredentialsProvider, error) {
+	if key == "" {
+		return nil, ErrEmptyAPIKey
+	}
+	if header == "" {
+		header = DefaultAPIKeyHeader
+	}
+
+	// gRPC metadata keys are lower case
+	return &APIKeyCredentialsProvider{header: strings.ToLower(header), key: key}, nil
+}
+
+// ApplyCredentials adds the key to the configured header.
+func (p *APIKeyCredentialsProvider) ApplyCredentials(_ context.Context, headers map[string]string) error {
+	headers[p.header] = p.key
+	return nil
+}
+
+// ShouldRetryRequest always returns false, as the key never changes.
+func (p *APIKeyCredentialsProvider) ShouldRetryRequest(_ context.Context, _ error) bool {
+	return false
+}
+
+// FileTokenCredentialsProvider adds a bearer token read from a file to the authorization header of each call, e.g.
+// a projected service account token. The file is read again once the token expires, according to the 'exp' claim if
+// the token is a JWT and otherwise after DefaultFileTokenRefreshInterval, or when a call is rejected as unauthenticated.
+type FileTokenCredentialsProvider struct {
+	path string
+
+	lock   sync.Mutex
+	token  string
+	expiry time.Time
+}
+
+// NewFileTokenCredentialsProvider returns a provider for the token stored in the file at the given path, which must be
+// readable. Can be configured with the environment variable 'ZEEBE_TOKEN_PATH'.
+func NewFileTokenCredentialsProvider(path string) (*FileTokenCredentialsProvider, error) {
+	provider := &FileTokenCredentialsProvider{path: path}
+	if _, err := provider.readToken(); err != nil {
+		return nil, err
+	}
+
+	return provider, nil
+}
+
+// ApplyCredentials adds the token to the authorization header, reading it again from the file if it expired.
+func (p *FileTokenCredentialsProvider) ApplyCredentials(_ context.Context, headers map[string]string) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if !time.Now().Before(p.expiry) {
+		if _, err := p.readTokenLocked(); err != nil {
+			return err
+		}
+	}
+
+	headers["Authorization"] = "Bearer " + p.token
+	return nil
+}
+
+// ShouldRetryRequest reads the token again if the call was rejected as unauthenticated, and returns true if it changed.
+func (p *FileTokenCredentialsProvider) ShouldRetryRequest(_ context.Context, err error) bool {
+	if status.Code(err) != codes.Unauthenticated {
+		return false
+	}
+
+	updated, err := p.readToken()
+	if err != nil {
+		log.Printf("Expected to read token after UNAUTHENTICATED response but: %s", err.Error())
+		return false
+	}
+
+	return updated
+}
+
+func (p *FileTokenCredentialsProvider) readToken() (bool, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	return p.readTokenLocked()
+}
+
+func (p *FileTokenCredentialsProvider) readTokenLocked() (bool, error) {
+	contents, err := os.ReadFile(p.path)
+	if err != nil {
+		return false, fmt.Errorf("failed to read token from %s: %w", p.path, err)
+	}
+
+	token := strings.TrimSpace(string(contents))
+	if token == "" {
+		return false, fmt.Errorf("%w in %s", ErrEmptyBearerToken, p.path)
+	}
+
+	updated := token != p.token
+	p.token = token
+	p.expiry = getTokenExpiry(token)
+	return updated, nil
+}
+
+// returns the expiry of the token from its 'exp' claim if it is a JWT, or the next refresh interval otherwise
+func getTokenExpiry(token string) time.Time {
+	var claims struct {
+		Exp int64 `json:"exp"`
+	}
+
+	parts := strings.Split(token, ".")
+	if len(parts) == 3 {
+		if payload, err := base64.RawURLEncoding.DecodeString(parts[1]); err == nil {
+			if err = json.Unmarshal(payload, &claims); err == nil && claims.Exp > 0 {
+				return time.Unix(claims.Exp, 0)
+			}
+		}
+	}
+
+	return time.Now().Add(DefaultFileTokenRefreshInterval)
+}
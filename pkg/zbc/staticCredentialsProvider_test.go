@@ -0,0 +1,162 @@
+// Copyright © 2018 Camunda Services GmbH (info@camunda.com)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package zbc
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type staticCredsProviderTestSuite struct {
+	*envSuite
+}
+
+func TestStaticCredentialsProviderSuite(t *testing.T) {
+	suite.Run(t, &staticCredsProviderTestSuite{new(envSuite)})
+}
+
+func (s *staticCredsProviderTestSuite) TestBearerTokenCredentialsProvider() {
+	// given
+	provider, err := NewBearerTokenCredentialsProvider(accessToken)
+	s.Require().NoError(err)
+
+	// when
+	headers := make(map[string]string)
+	err = provider.ApplyCredentials(context.Background(), headers)
+
+	// then
+	s.NoError(err)
+	s.Equal("Bearer "+accessToken, headers["Authorization"])
+	s.False(provider.ShouldRetryRequest(context.Background(), status.Error(codes.Unauthenticated, "expired")))
+}
+
+func (s *staticCredsProviderTestSuite) TestBearerTokenCredentialsProviderRequiresToken() {
+	_, err := NewBearerTokenCredentialsProvider("")
+	s.ErrorIs(err, ErrEmptyBearerToken)
+}
+
+func (s *staticCredsProviderTestSuite) TestAPIKeyCredentialsProvider() {
+	// given
+	provider, err := NewAPIKeyCredentialsProvider("X-Custom-Key", "key")
+	s.Require().NoError(err)
+
+	// when
+	headers := make(map[string]string)
+	err = provider.ApplyCredentials(context.Background(), headers)
+
+	// then
+	s.NoError(err)
+	s.Equal(map[string]string{"x-custom-key": "key"}, headers)
+}
+
+func (s *staticCredsProviderTestSuite) TestAPIKeyCredentialsProviderDefaultHeader() {
+	// given
+	provider, err := NewAPIKeyCredentialsProvider("", "key")
+	s.Require().NoError(err)
+
+	// when
+	headers := make(map[string]string)
+	err = provider.ApplyCredentials(context.Background(), headers)
+
+	// then
+	s.NoError(err)
+	s.Equal("key", headers[DefaultAPIKeyHeader])
+}
+
+func (s *staticCredsProviderTestSuite) TestFileTokenCredentialsProviderRereadsExpiredToken() {
+	// given
+	tokenPath := filepath.Join(s.T().TempDir(), "token")
+	expired := createUnsignedJWT(time.Now().Add(-time.Minute))
+	s.Require().NoError(os.WriteFile(tokenPath, []byte(expired+"\n"), 0600))
+	provider, err := NewFileTokenCredentialsProvider(tokenPath)
+	s.Require().NoError(err)
+
+	// when
+	renewed := createUnsignedJWT(time.Now().Add(time.Hour))
+	s.Require().NoError(os.WriteFile(tokenPath, []byte(renewed), 0600))
+	headers := make(map[string]string)
+	err = provider.ApplyCredentials(context.Background(), headers)
+
+	// then
+	s.NoError(err)
+	s.Equal("Bearer "+renewed, headers["Authorization"])
+
+	// a valid token is not read again
+	s.Require().NoError(os.WriteFile(tokenPath, []byte("other"), 0600))
+	s.NoError(provider.ApplyCredentials(context.Background(), headers))
+	s.Equal("Bearer "+renewed, headers["Authorization"])
+}
+
+func (s *staticCredsProviderTestSuite) TestFileTokenCredentialsProviderRetriesWithNewToken() {
+	// given
+	tokenPath := filepath.Join(s.T().TempDir(), "token")
+	s.Require().NoError(os.WriteFile(tokenPath, []byte("first"), 0600))
+	provider, err := NewFileTokenCredentialsProvider(tokenPath)
+	s.Require().NoError(err)
+	unauthenticated := status.Error(codes.Unauthenticated, "expired")
+
+	// then
+	s.False(provider.ShouldRetryRequest(context.Background(), unauthenticated))
+	s.Require().NoError(os.WriteFile(tokenPath, []byte("second"), 0600))
+	s.False(provider.ShouldRetryRequest(context.Background(), status.Error(codes.Unavailable, "unavailable")))
+	s.True(provider.ShouldRetryRequest(context.Background(), unauthenticated))
+
+	headers := make(map[string]string)
+	s.NoError(provider.ApplyCredentials(context.Background(), headers))
+	s.Equal("Bearer second", headers["Authorization"])
+}
+
+func (s *staticCredsProviderTestSuite) TestFileTokenCredentialsProviderRequiresFile() {
+	_, err := NewFileTokenCredentialsProvider(filepath.Join(s.T().TempDir(), "missing"))
+	s.ErrorIs(err, os.ErrNotExist)
+}
+
+func (s *staticCredsProviderTestSuite) TestCredentialsProviderFromEnvironment() {
+	tokenPath := filepath.Join(s.T().TempDir(), "token")
+	s.Require().NoError(os.WriteFile(tokenPath, []byte(accessToken), 0600))
+
+	env.set(TokenPathEnvVar, tokenPath)
+	s.IsType(&FileTokenCredentialsProvider{}, s.newClientCredentialsProvider())
+
+	env.set(APIKeyEnvVar, "key")
+	env.set(APIKeyHeaderEnvVar, "authorization-key")
+	provider := s.newClientCredentialsProvider()
+	s.Equal(&APIKeyCredentialsProvider{header: "authorization-key", key: "key"}, provider)
+
+	env.set(BearerTokenEnvVar, accessToken)
+	s.Equal(&BearerTokenCredentialsProvider{token: accessToken}, s.newClientCredentialsProvider())
+}
+
+func (s *staticCredsProviderTestSuite) newClientCredentialsProvider() CredentialsProvider {
+	client, err := NewClient(&ClientConfig{GatewayAddress: "0.0.0.0:0", UsePlaintextConnection: true})
+	s.Require().NoError(err)
+	defer client.Close()
+
+	return client.(*ClientImpl).credentialsProvider
+}
+
+func createUnsignedJWT(expiry time.Time) string {
+	encode := base64.RawURLEncoding.EncodeToString
+	payload := fmt.Sprintf(`{"sub":"zeebe","exp":%d}`, expiry.Unix())
+	return encode([]byte(`{"alg":"none"}`)) + "." + encode([]byte(payload)) + "."
+}